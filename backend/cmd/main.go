@@ -12,10 +12,15 @@ import (
 	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/excel"
 	"github.com/pesos228/bug-tracker/internal/handler"
+	"github.com/pesos228/bug-tracker/internal/jobs"
 	"github.com/pesos228/bug-tracker/internal/notification"
+	"github.com/pesos228/bug-tracker/internal/pdf"
 	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store/filestore"
 	"github.com/pesos228/bug-tracker/internal/store/psqlstore"
 	"github.com/pesos228/bug-tracker/internal/store/redisstore"
+	"github.com/pesos228/bug-tracker/internal/textreport"
+	"github.com/pesos228/bug-tracker/internal/webhook"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -48,14 +53,38 @@ func main() {
 
 	sessionTTL := time.Duration(cfg.Auth.SSOMaxLifespanSeconds) * time.Second
 
-	reportGenerator := excel.NewReportGenerator()
-	emailNotifier := notification.NewEmailNotifier(cfg.Smtp.Host, cfg.Smtp.Port, cfg.Smtp.Username, cfg.Smtp.Password, cfg.Smtp.From, cfg.AppPublicUrl)
+	excelGenerator := excel.NewReportGenerator()
+	pdfGenerator := pdf.NewReportGenerator()
+	csvGenerator := textreport.NewCSVGenerator()
+	jsonGenerator := textreport.NewJSONGenerator()
+	markdownGenerator := textreport.NewMarkdownGenerator()
 
 	stateStore := redisstore.NewRedisStateStore(redisClient)
 	sessionStore := redisstore.NewRedisSessionStore(redisClient, sessionTTL)
+	eventStream := redisstore.NewRedisEventStream(redisClient, cfg.Realtime.HistoryLimit)
 	userStore := psqlstore.NewPsqlUserStore(psqlDb)
 	folderStore := psqlstore.NewPsqlFolderStore(psqlDb)
 	taskStore := psqlstore.NewPsqlTaskStore(psqlDb)
+	folderMemberStore := psqlstore.NewPsqlFolderMemberStore(psqlDb)
+	snapshotStore := psqlstore.NewPsqlSnapshotStore(psqlDb)
+	reportJobStore := psqlstore.NewPsqlReportJobStore(psqlDb)
+	reportFileStore, err := filestore.NewReportFileStore(cfg.Reports.StorageDir)
+	if err != nil {
+		log.Fatalf("Failed to init report storage: %v", err)
+	}
+	reportScheduleStore := psqlstore.NewPsqlReportScheduleStore(psqlDb)
+	reportTemplateStore := psqlstore.NewPsqlReportTemplateStore(psqlDb)
+	notificationStore := psqlstore.NewPsqlNotificationStore(psqlDb)
+	inboxStore := psqlstore.NewPsqlInboxStore(psqlDb)
+	notificationSettingsStore := psqlstore.NewPsqlNotificationSettingsStore(psqlDb)
+	webhookStore := psqlstore.NewPsqlWebhookStore(psqlDb)
+	webhookDeliveryStore := psqlstore.NewPsqlWebhookDeliveryStore(psqlDb)
+	emailTemplateStore := psqlstore.NewPsqlEmailTemplateStore(psqlDb)
+	mentionStore := psqlstore.NewPsqlMentionStore(psqlDb)
+	txManager := psqlstore.NewPsqlTxManager(psqlDb)
+
+	emailRenderer := notification.NewEmailRenderer(emailTemplateStore, cfg.AppPublicUrl)
+	notifier := newNotifier(cfg, emailRenderer)
 
 	authService := service.NewAuthService(&service.AuthServiceDeps{
 		AuthClient:   authClient,
@@ -65,22 +94,105 @@ func main() {
 		AuthConfig:   &cfg.Auth,
 		AppPublicUrl: cfg.AppPublicUrl,
 	})
-	folderService := service.NewFolderService(folderStore)
-	taskService := service.NewTaskService(taskStore, userStore, folderStore, emailNotifier)
-	userService := service.NewUserService(userStore, taskStore)
-	reportService := service.NewReportService(folderStore, taskStore, reportGenerator)
+	permissionPolicy, err := service.NewPermissionPolicy(cfg.RolePermissions)
+	if err != nil {
+		log.Fatalf("Invalid role permissions: %v", err)
+	}
+
+	accessService := service.NewAccessService(folderMemberStore, taskStore, mentionStore)
+	notificationService := service.NewNotificationService(&service.NotificationServiceDeps{
+		NotificationStore: notificationStore,
+		InboxStore:        inboxStore,
+		SettingsStore:     notificationSettingsStore,
+		UserStore:         userStore,
+		TaskStore:         taskStore,
+		FolderStore:       folderStore,
+		TxManager:         txManager,
+		Notifier:          notifier,
+		MaxAttempts:       cfg.Notifications.MaxAttempts,
+	})
+	webhookService := service.NewWebhookService(&service.WebhookServiceDeps{
+		WebhookStore:  webhookStore,
+		DeliveryStore: webhookDeliveryStore,
+		FolderStore:   folderStore,
+		Sender:        webhook.NewHTTPSender(cfg.Webhooks.Timeout),
+		MaxAttempts:   cfg.Webhooks.MaxAttempts,
+	})
+	realtimeService := service.NewRealtimeService(eventStream)
+	emailTemplateService := service.NewEmailTemplateService(emailTemplateStore, emailRenderer)
+	folderService := service.NewFolderService(&service.FolderServiceDeps{
+		FolderStore:         folderStore,
+		MemberStore:         folderMemberStore,
+		UserStore:           userStore,
+		TaskStore:           taskStore,
+		TemplateStore:       reportTemplateStore,
+		TxManager:           txManager,
+		NotificationService: notificationService,
+		WebhookService:      webhookService,
+	})
+	taskService := service.NewTaskService(taskStore, userStore, folderStore, mentionStore, notificationService, webhookService, realtimeService, txManager)
+	userService := service.NewUserService(userStore, taskStore, folderMemberStore)
+	reportService := service.NewReportService(
+		folderStore,
+		taskStore,
+		reportTemplateStore,
+		excel.NewSummaryGenerator(),
+		excelGenerator,
+		pdfGenerator,
+		csvGenerator,
+		jsonGenerator,
+		markdownGenerator,
+	)
+	statsService := service.NewStatsService(folderStore, taskStore, snapshotStore)
+	reportTemplateService := service.NewReportTemplateService(reportTemplateStore)
+
+	reportJobService := service.NewReportJobService(reportJobStore, reportFileStore, reportService, userStore, permissionPolicy, cfg.Reports.Workers, cfg.Reports.ResultTTL)
+	reportScheduleService := service.NewReportScheduleService(&service.ReportScheduleServiceDeps{
+		ScheduleStore:    reportScheduleStore,
+		UserStore:        userStore,
+		ReportService:    reportService,
+		AccessService:    accessService,
+		PermissionPolicy: permissionPolicy,
+		TxManager:        txManager,
+		EmailNotifier:    notifier,
+		RecipientDomains: cfg.Reports.RecipientDomains,
+	})
+
+	go jobs.RunPeriodically(ctx, "burndown_snapshots", cfg.Jobs.SnapshotInterval, statsService.CaptureSnapshots)
+	go jobs.RunPeriodically(ctx, "report_jobs_cleanup", time.Hour, reportJobService.CleanupExpired)
+	go reportJobService.RunWorkers(ctx)
+	go jobs.RunPeriodically(ctx, "report_schedules", time.Minute, reportScheduleService.RunDue)
+	go jobs.RunPeriodically(ctx, "notification_outbox", cfg.Notifications.PollInterval, notificationService.ProcessDue)
+	go jobs.RunPeriodically(ctx, "notification_digests", time.Minute, notificationService.SendDigests)
+	go jobs.RunPeriodically(ctx, "webhook_deliveries", cfg.Webhooks.PollInterval, webhookService.ProcessDue)
+	go realtimeService.Run(ctx)
 
 	authHandler := handler.NewAuthHandler(authService, sessionTTL)
 	folderHandler := handler.NewFolderHandler(folderService, reportService)
-	taskHandler := handler.NewTaskHandler(taskService)
-	userHandler := handler.NewUserHandler(userService)
+	taskHandler := handler.NewTaskHandler(taskService, accessService)
+	userHandler := handler.NewUserHandler(userService, notificationService)
+	statsHandler := handler.NewStatsHandler(statsService)
+	reportJobHandler := handler.NewReportJobHandler(reportJobService)
+	reportScheduleHandler := handler.NewReportScheduleHandler(reportScheduleService)
+	reportTemplateHandler := handler.NewReportTemplateHandler(reportTemplateService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	realtimeHandler := handler.NewRealtimeHandler(realtimeService, cfg.Realtime.Heartbeat)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(emailTemplateService)
 
 	authMiddleware := appmw.AuthMiddleware(sessionStore, authClient, authService, userStore)
+	folderPermission := func(permission domain.Permission) func(http.Handler) http.Handler {
+		return appmw.RequireFolderPermission(accessService, permission)
+	}
+	taskPermission := func(permission domain.Permission) func(http.Handler) http.Handler {
+		return appmw.RequireTaskPermission(accessService, permission)
+	}
 
 	r := chi.NewRouter()
 
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	r.Use(appmw.Localize)
 
 	r.Get("/api/auth/login-url", authHandler.HandleLogin)
 	r.Get("/auth/callback", authHandler.HandleCallback)
@@ -88,31 +200,81 @@ func main() {
 
 	r.Group(func(r chi.Router) {
 		r.Use(authMiddleware)
+		r.Use(appmw.ResolvePermissions(permissionPolicy))
+		r.Use(appmw.ResolveUserLocale(userService))
 
 		r.Get("/api/users/me", userHandler.AboutUser)
+		r.Patch("/api/users/me", userHandler.UpdateProfile)
 		r.Get("/api/users/me/stats", userHandler.Stats)
+		r.Get("/api/users/me/notification-settings", notificationHandler.GetSettings)
+		r.Put("/api/users/me/notification-settings", notificationHandler.UpdateSettings)
+		r.Get("/api/users/me/events", realtimeHandler.UserEvents)
 		r.Get("/api/tasks/my", taskHandler.ListUserTasks)
-		r.Get("/api/tasks/{id}", taskHandler.Details)
-		r.Patch("/api/tasks/{id}/review", taskHandler.UpdateByUser)
-	})
+		r.With(taskPermission(domain.PermTaskRead)).Get("/api/tasks/{id}", taskHandler.Details)
+		r.With(appmw.RequirePermission(domain.PermTaskReview)).Patch("/api/tasks/{id}/review", taskHandler.UpdateByUser)
 
-	r.Group(func(r chi.Router) {
-		r.Use(authMiddleware)
-		r.Use(appmw.AdminOnly)
+		r.With(appmw.RequireAnyFolderPermission(accessService, domain.PermUserList)).Get("/api/users", userHandler.Search)
 
-		r.Get("/api/users", userHandler.Search)
+		r.With(appmw.RequirePermission(domain.PermAnalyticsRead)).Get("/api/analytics/team", statsHandler.TeamWorkload)
 
-		r.Post("/api/folders", folderHandler.Create)
+		r.With(appmw.RequirePermission(domain.PermFolderCreate)).Post("/api/folders", folderHandler.Create)
 		r.Get("/api/folders", folderHandler.Search)
-		r.Get("/api/folders/{id}", folderHandler.Details)
-		r.Get("/api/folders/{id}/tasks", taskHandler.ListByFolder)
-		r.Delete("/api/folders/{id}", folderHandler.Delete)
+		r.With(folderPermission(domain.PermFolderRead)).Get("/api/folders/{id}", folderHandler.Details)
+		r.With(folderPermission(domain.PermTaskRead)).Get("/api/folders/{id}/tasks", taskHandler.ListByFolder)
+		r.With(folderPermission(domain.PermTaskRead)).Get("/api/folders/{id}/events", realtimeHandler.FolderEvents)
+		r.With(folderPermission(domain.PermFolderRead)).Get("/api/folders/{id}/stats", statsHandler.FolderStats)
+		r.With(folderPermission(domain.PermFolderRead)).Get("/api/folders/{id}/burndown", statsHandler.Burndown)
+		r.With(folderPermission(domain.PermFolderUpdate)).Patch("/api/folders/{id}", folderHandler.Update)
+		r.With(appmw.RequirePermission(domain.PermFolderCreate), folderPermission(domain.PermFolderRead)).Post("/api/folders/{id}/clone", folderHandler.Clone)
+		r.With(folderPermission(domain.PermFolderDelete)).Delete("/api/folders/{id}", folderHandler.Delete)
+
+		r.With(folderPermission(domain.PermFolderRead)).Get("/api/folders/{id}/members", folderHandler.ListMembers)
+		r.With(folderPermission(domain.PermFolderMembers)).Post("/api/folders/{id}/members", folderHandler.AddMember)
+		r.With(folderPermission(domain.PermFolderMembers)).Patch("/api/folders/{id}/members/{userId}", folderHandler.UpdateMember)
+		r.With(folderPermission(domain.PermFolderMembers)).Delete("/api/folders/{id}/members/{userId}", folderHandler.RemoveMember)
 
-		r.Post("/api/folders/{id}/tasks", taskHandler.Create)
-		r.Patch("/api/tasks/{id}", taskHandler.UpdateByAdmin)
-		r.Delete("/api/tasks/{id}", taskHandler.Delete)
+		r.With(folderPermission(domain.PermTaskCreate)).Post("/api/folders/{id}/tasks", taskHandler.Create)
+		r.With(taskPermission(domain.PermTaskUpdate)).Patch("/api/tasks/{id}", taskHandler.UpdateByAdmin)
+		r.With(taskPermission(domain.PermTaskDelete)).Delete("/api/tasks/{id}", taskHandler.Delete)
 
-		r.Get("/api/folders/{id}/reports", folderHandler.Download)
+		r.With(folderPermission(domain.PermReportDownload)).Get("/api/folders/{id}/reports", folderHandler.Download)
+		r.With(appmw.RequireAnyFolderPermission(accessService, domain.PermReportDownload)).Get("/api/reports/summary", folderHandler.DownloadSummary)
+		r.With(folderPermission(domain.PermReportDownload)).Post("/api/folders/{id}/report-jobs", reportJobHandler.CreateFolderJob)
+		r.With(appmw.RequireAnyFolderPermission(accessService, domain.PermReportDownload)).Post("/api/reports/summary/jobs", reportJobHandler.CreateSummaryJob)
+		r.Get("/api/report-jobs/{id}", reportJobHandler.Get)
+		r.Get("/api/report-jobs/{id}/file", reportJobHandler.Download)
+
+		r.With(appmw.RequireAnyFolderPermission(accessService, domain.PermReportDownload)).Post("/api/report-schedules", reportScheduleHandler.Create)
+		r.Get("/api/report-schedules", reportScheduleHandler.List)
+		r.Get("/api/report-schedules/{id}", reportScheduleHandler.Get)
+		r.Patch("/api/report-schedules/{id}", reportScheduleHandler.Update)
+		r.Delete("/api/report-schedules/{id}", reportScheduleHandler.Delete)
+		r.Get("/api/report-schedules/{id}/runs", reportScheduleHandler.ListRuns)
+
+		r.Get("/api/report-templates", reportTemplateHandler.List)
+		r.With(appmw.RequirePermission(domain.PermReportTemplates)).Post("/api/report-templates", reportTemplateHandler.Create)
+		r.With(appmw.RequirePermission(domain.PermReportTemplates)).Patch("/api/report-templates/{id}", reportTemplateHandler.Update)
+		r.With(appmw.RequirePermission(domain.PermReportTemplates)).Delete("/api/report-templates/{id}", reportTemplateHandler.Delete)
+
+		r.With(appmw.RequirePermission(domain.PermEmailTemplates)).Get("/api/email-templates", emailTemplateHandler.List)
+		r.With(appmw.RequirePermission(domain.PermEmailTemplates)).Get("/api/email-templates/{name}/{locale}", emailTemplateHandler.Get)
+		r.With(appmw.RequirePermission(domain.PermEmailTemplates)).Put("/api/email-templates/{name}/{locale}", emailTemplateHandler.Update)
+		r.With(appmw.RequirePermission(domain.PermEmailTemplates)).Delete("/api/email-templates/{name}/{locale}", emailTemplateHandler.Reset)
+		r.With(appmw.RequirePermission(domain.PermEmailTemplates)).Post("/api/email-templates/{name}/{locale}/preview", emailTemplateHandler.Preview)
+
+		r.Get("/api/notifications", notificationHandler.ListInbox)
+		r.Post("/api/notifications/read-all", notificationHandler.MarkAllRead)
+		r.Post("/api/notifications/{id}/read", notificationHandler.MarkRead)
+		r.With(appmw.RequirePermission(domain.PermNotifications)).Get("/api/notifications/dead-letters", notificationHandler.ListDeadLetters)
+		r.With(appmw.RequirePermission(domain.PermNotifications)).Post("/api/notifications/{id}/retry", notificationHandler.Retry)
+
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Get("/api/webhooks", webhookHandler.List)
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Post("/api/webhooks", webhookHandler.Create)
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Get("/api/webhooks/{id}", webhookHandler.Get)
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Patch("/api/webhooks/{id}", webhookHandler.Update)
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Delete("/api/webhooks/{id}", webhookHandler.Delete)
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Get("/api/webhooks/{id}/deliveries", webhookHandler.ListDeliveries)
+		r.With(appmw.RequirePermission(domain.PermWebhooks)).Post("/api/webhooks/{id}/test", webhookHandler.SendTest)
 	})
 
 	log.Println("Server started on", cfg.AppPort)
@@ -121,9 +283,71 @@ func main() {
 	}
 }
 
+func newNotifier(cfg *config.Config, emailRenderer notification.EmailRenderer) notification.Notifier {
+	client := &http.Client{Timeout: cfg.Notifications.Chat.Timeout}
+
+	var channels []notification.Channel
+	for _, channel := range cfg.Notifications.Channels {
+		switch channel {
+		case "email":
+			channels = append(channels, notification.Channel{Name: channel, Notifier: notification.NewEmailNotifier(cfg.Smtp.Host, cfg.Smtp.Port, cfg.Smtp.Username, cfg.Smtp.Password, cfg.Smtp.From, cfg.AppPublicUrl, emailRenderer)})
+		case "mattermost":
+			channels = append(channels, notification.Channel{Name: channel, Notifier: notification.NewMattermostNotifier(cfg.Notifications.Chat.MattermostWebhookURL, cfg.AppPublicUrl, client)})
+		case "slack":
+			channels = append(channels, notification.Channel{Name: channel, Notifier: notification.NewSlackNotifier(cfg.Notifications.Chat.SlackWebhookURL, cfg.AppPublicUrl, client)})
+		case "telegram":
+			channels = append(channels, notification.Channel{Name: channel, Notifier: notification.NewTelegramNotifier(cfg.Notifications.Chat.TelegramAPIURL, cfg.Notifications.Chat.TelegramBotToken, cfg.AppPublicUrl, client)})
+		}
+	}
+
+	log.Printf("Notification channels: %v", cfg.Notifications.Channels)
+	return notification.NewMultiNotifier(channels...)
+}
+
 func migrateTables(db *gorm.DB) {
 	db.AutoMigrate(domain.User{})
 	db.AutoMigrate(domain.Task{})
 	db.AutoMigrate(domain.Folder{})
+	db.AutoMigrate(domain.FolderMember{})
+	db.AutoMigrate(domain.FolderSnapshot{})
+	db.AutoMigrate(domain.ReportJob{})
+	if db.Migrator().HasColumn(&domain.ReportJob{}, "data") {
+		db.Migrator().DropColumn(&domain.ReportJob{}, "data")
+	}
+	db.AutoMigrate(domain.ReportSchedule{})
+	if db.Migrator().HasColumn(&domain.ReportSchedule{}, "permissions") {
+		db.Migrator().DropColumn(&domain.ReportSchedule{}, "permissions")
+	}
+	db.AutoMigrate(domain.ReportScheduleRun{})
+	db.AutoMigrate(domain.ReportTemplate{})
 	db.AutoMigrate(domain.Task{})
+	db.AutoMigrate(domain.Notification{})
+	db.AutoMigrate(domain.InboxItem{})
+	db.AutoMigrate(domain.NotificationSettings{})
+	db.AutoMigrate(domain.Webhook{})
+	db.AutoMigrate(domain.WebhookDelivery{})
+	db.AutoMigrate(domain.EmailTemplate{})
+	db.AutoMigrate(domain.TaskMention{})
+
+	backfillFolderOwners(db)
+}
+
+func backfillFolderOwners(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE folders SET owner_id = created_by WHERE owner_id IS NULL`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			INSERT INTO folder_members (id, folder_id, user_id, role, created_at)
+			SELECT gen_random_uuid(), f.id, f.owner_id, ?, now()
+			FROM folders f
+			WHERE NOT EXISTS (
+				SELECT 1 FROM folder_members m WHERE m.folder_id = f.id AND m.role = ?
+			)
+			ON CONFLICT (folder_id, user_id) DO UPDATE SET role = EXCLUDED.role`,
+			domain.FolderOwner, domain.FolderOwner).Error
+	})
+	if err != nil {
+		log.Fatalf("Failed to backfill folder owners: %v", err)
+	}
 }
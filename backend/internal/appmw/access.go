@@ -0,0 +1,73 @@
+package appmw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+func PrincipalFromContext(ctx context.Context) (*service.Principal, bool) {
+	userID, ok := UserIdFromContext(ctx)
+	if !ok || userID == "" {
+		return nil, false
+	}
+	permissions, _ := UserPermissionsFromContext(ctx)
+	roles, _ := UserRolesFromContext(ctx)
+
+	return &service.Principal{
+		UserID:      userID,
+		IsAdmin:     service.IsAdminRole(roles),
+		Permissions: permissions,
+	}, true
+}
+
+func RequireFolderPermission(accessService service.AccessService, permission domain.Permission) func(http.Handler) http.Handler {
+	return requireAccess(func(ctx context.Context, principal *service.Principal, id string) error {
+		return accessService.AuthorizeFolder(ctx, principal, id, permission)
+	})
+}
+
+func RequireTaskPermission(accessService service.AccessService, permission domain.Permission) func(http.Handler) http.Handler {
+	return requireAccess(func(ctx context.Context, principal *service.Principal, id string) error {
+		return accessService.AuthorizeTask(ctx, principal, id, permission)
+	})
+}
+
+func RequireAnyFolderPermission(accessService service.AccessService, permission domain.Permission) func(http.Handler) http.Handler {
+	return requireAccess(func(ctx context.Context, principal *service.Principal, _ string) error {
+		return accessService.AuthorizeAnyFolder(ctx, principal, permission)
+	})
+}
+
+func requireAccess(authorize func(ctx context.Context, principal *service.Principal, id string) error) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				httpMessage(w, r, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			if err := authorize(r.Context(), principal, chi.URLParam(r, "id")); err != nil {
+				switch {
+				case errors.Is(err, service.ErrAccessDenied):
+					httpMessage(w, r, "Forbidden", http.StatusForbidden)
+				case errors.Is(err, store.ErrTaskNotFound), errors.Is(err, store.ErrFolderNotFound):
+					locale := i18n.FromContext(r.Context())
+					http.Error(w, i18n.LocalizeError(locale, err, store.ErrTaskNotFound, store.ErrFolderNotFound), http.StatusNotFound)
+				default:
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
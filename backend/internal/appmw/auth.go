@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -24,17 +25,17 @@ func AuthMiddleware(sessionStore store.SessionStore,
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			sessionId, err := r.Cookie("session_id")
 			if err != nil || sessionId == nil {
-				sendUnauthorized(w)
+				sendUnauthorized(w, r)
 				return
 			}
 
 			sessionData, err := sessionStore.GetSession(r.Context(), sessionId.Value)
 			if err != nil {
 				if err == store.ErrSessionNotFound {
-					sendUnauthorized(w)
+					sendUnauthorized(w, r)
 					return
 				}
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				httpMessage(w, r, "Internal Server Error", http.StatusInternalServerError)
 				return
 			}
 
@@ -44,20 +45,20 @@ func AuthMiddleware(sessionStore store.SessionStore,
 				if errors.As(err, &tokenExpiredError) {
 					newVerifiedToken, refreshErr := handleTokenRefresh(r, sessionId.Value, sessionData, authService, sessionStore, authClient)
 					if refreshErr != nil {
-						sendUnauthorized(w)
+						sendUnauthorized(w, r)
 						return
 					}
 					verifiedToken = newVerifiedToken
 					go syncUser(context.Background(), newVerifiedToken, userStore)
 				} else {
-					sendUnauthorized(w)
+					sendUnauthorized(w, r)
 					return
 				}
 			}
 
 			var claims dto.IdTokenClaims
 			if err := verifiedToken.Claims(&claims); err != nil {
-				http.Error(w, "Failed to parse token claims", http.StatusInternalServerError)
+				httpMessage(w, r, "Failed to parse token claims", http.StatusInternalServerError)
 				return
 			}
 
@@ -66,13 +67,14 @@ func AuthMiddleware(sessionStore store.SessionStore,
 			ctx = context.WithValue(ctx, KeyGivenName, claims.GivenName)
 			ctx = context.WithValue(ctx, KeyFamilyName, claims.FamilyName)
 			ctx = context.WithValue(ctx, KeyUserRoles, claims.RealmAccess.Roles)
+			ctx = context.WithValue(ctx, KeyLocale, claims.Locale)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-func sendUnauthorized(w http.ResponseWriter) {
+func sendUnauthorized(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, &http.Cookie{
 		Name:   "session_id",
 		Value:  "",
@@ -80,7 +82,7 @@ func sendUnauthorized(w http.ResponseWriter) {
 		MaxAge: -1,
 	})
 
-	http.Error(w, "Authentication failed", http.StatusUnauthorized)
+	httpMessage(w, r, "Authentication failed", http.StatusUnauthorized)
 }
 
 func handleTokenRefresh(r *http.Request,
@@ -131,7 +133,7 @@ func syncUser(ctx context.Context, token *oidc.IDToken, userStore store.UserStor
 		return
 	}
 
-	newUser, err := domain.NewUser(token.Subject, claims.Email, claims.GivenName, claims.FamilyName)
+	newUser, err := domain.NewUser(token.Subject, claims.Email, claims.GivenName, claims.FamilyName, claims.Locale, claims.RealmAccess.Roles)
 	if err != nil {
 		log.Printf("SYNC_USER_ERROR: failed to create new user due to validation: %v", err)
 		return
@@ -139,7 +141,7 @@ func syncUser(ctx context.Context, token *oidc.IDToken, userStore store.UserStor
 	dbUser, err := userStore.FindById(ctx, token.Subject)
 	if err != nil {
 		if errors.Is(err, store.ErrUserNotFound) {
-			if err := userStore.Save(ctx, newUser); err != nil {
+			if err := userStore.SyncIdentity(ctx, newUser); err != nil {
 				log.Printf("SYNC_USER_ERROR: db error: %v", err)
 			}
 			return
@@ -148,9 +150,9 @@ func syncUser(ctx context.Context, token *oidc.IDToken, userStore store.UserStor
 		return
 	}
 
-	if newUser.ID != dbUser.ID || newUser.Email != dbUser.Email || newUser.FirstName != dbUser.FirstName || newUser.LastName != dbUser.LastName {
+	if newUser.ID != dbUser.ID || newUser.Email != dbUser.Email || newUser.FirstName != dbUser.FirstName || newUser.LastName != dbUser.LastName || newUser.Locale != dbUser.Locale || !slices.Equal(newUser.Roles, dbUser.Roles) {
 		log.Printf("SYNC_USER_INFO: User %s data is outdated. Updating.", dbUser.ID)
-		if err := userStore.Save(ctx, newUser); err != nil {
+		if err := userStore.SyncIdentity(ctx, newUser); err != nil {
 			log.Printf("SYNC_USER_ERROR: db error: %v", err)
 		}
 	}
@@ -1,6 +1,10 @@
 package appmw
 
-import "context"
+import (
+	"context"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
 
 type contextKey string
 
@@ -10,6 +14,8 @@ const (
 	KeyUserRoles  = contextKey("userRoles")
 	KeyGivenName  = contextKey("givenName")
 	KeyFamilyName = contextKey("familyName")
+	KeyUserPerms  = contextKey("userPermissions")
+	KeyLocale     = contextKey("locale")
 )
 
 func UserIdFromContext(ctx context.Context) (string, bool) {
@@ -31,3 +37,13 @@ func UserLastNameFromContext(ctx context.Context) (string, bool) {
 	lastName, ok := ctx.Value(KeyFamilyName).(string)
 	return lastName, ok
 }
+
+func UserPermissionsFromContext(ctx context.Context) ([]domain.Permission, bool) {
+	permissions, ok := ctx.Value(KeyUserPerms).([]domain.Permission)
+	return permissions, ok
+}
+
+func LocaleClaimFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(KeyLocale).(string)
+	return locale, ok
+}
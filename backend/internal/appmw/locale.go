@@ -0,0 +1,53 @@
+package appmw
+
+import (
+	"net/http"
+
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale, ok := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		if !ok {
+			locale = i18n.DefaultLocale
+		}
+
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
+
+// ResolveUserLocale prefers the profile locale, then the identity provider claim, over the negotiated one.
+func ResolveUserLocale(userService service.UserService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale, ok := userLocale(r, userService)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+		})
+	}
+}
+
+func userLocale(r *http.Request, userService service.UserService) (i18n.Locale, bool) {
+	if userID, ok := UserIdFromContext(r.Context()); ok {
+		if preferred, err := userService.PreferredLocale(r.Context(), userID); err == nil && preferred != "" {
+			if locale, ok := i18n.Parse(preferred); ok {
+				return locale, true
+			}
+		}
+	}
+
+	if claim, ok := LocaleClaimFromContext(r.Context()); ok {
+		return i18n.Parse(claim)
+	}
+	return "", false
+}
+
+func httpMessage(w http.ResponseWriter, r *http.Request, message string, status int) {
+	http.Error(w, i18n.TranslateError(i18n.FromContext(r.Context()), message), status)
+}
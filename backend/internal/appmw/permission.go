@@ -0,0 +1,32 @@
+package appmw
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+func ResolvePermissions(policy service.PermissionPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			roles, _ := UserRolesFromContext(r.Context())
+			ctx := context.WithValue(r.Context(), KeyUserPerms, policy.Resolve(roles))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func RequirePermission(permission domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok || !principal.Has(permission) {
+				httpMessage(w, r, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
@@ -28,13 +32,61 @@ type SmtpConfig struct {
 	From     string
 }
 
+type JobsConfig struct {
+	SnapshotInterval time.Duration
+}
+
+type ReportsConfig struct {
+	Workers          int
+	ResultTTL        time.Duration
+	RecipientDomains []string
+	StorageDir       string
+}
+
+type NotificationsConfig struct {
+	PollInterval time.Duration
+	MaxAttempts  int
+	Channels     []string
+	Chat         ChatConfig
+}
+
+type ChatConfig struct {
+	MattermostWebhookURL string
+	SlackWebhookURL      string
+	TelegramBotToken     string
+	TelegramAPIURL       string
+	Timeout              time.Duration
+}
+
+type WebhooksConfig struct {
+	PollInterval time.Duration
+	MaxAttempts  int
+	Timeout      time.Duration
+}
+
+type RealtimeConfig struct {
+	Heartbeat    time.Duration
+	HistoryLimit int
+}
+
 type Config struct {
-	Auth         AuthConfig
-	Smtp         SmtpConfig
-	RedisConfig  redis.Options
-	AppPort      string
-	AppPublicUrl string
-	DatabaseUrl  string
+	Auth            AuthConfig
+	Smtp            SmtpConfig
+	RedisConfig     redis.Options
+	AppPort         string
+	AppPublicUrl    string
+	DatabaseUrl     string
+	RolePermissions map[string][]string
+	Jobs            JobsConfig
+	Reports         ReportsConfig
+	Notifications   NotificationsConfig
+	Webhooks        WebhooksConfig
+	Realtime        RealtimeConfig
+}
+
+var defaultRolePermissions = map[string][]string{
+	"admin":   {"*"},
+	"default": {"task:review"},
 }
 
 func LoadFromEnv() *Config {
@@ -44,7 +96,8 @@ func LoadFromEnv() *Config {
 	}
 
 	var smtpCfg SmtpConfig
-	if getBoolEnv("SMTP_ENABLED") {
+	smtpEnabled := getBoolEnv("SMTP_ENABLED")
+	if smtpEnabled {
 		smtpCfg = SmtpConfig{
 			Host:     requireEnv("SMTP_HOST"),
 			Port:     getIntEnv("SMTP_PORT"),
@@ -56,6 +109,8 @@ func LoadFromEnv() *Config {
 		log.Println("SMTP is disabled, email notifications will not be sent")
 	}
 
+	channels := getNotificationChannelsEnv("NOTIFICATION_CHANNELS", smtpEnabled)
+
 	return &Config{
 		Auth: AuthConfig{
 			PublicBaseUrl:         requireEnv("KEYCLOAK_PUBLIC_BASE_URL"),
@@ -72,11 +127,100 @@ func LoadFromEnv() *Config {
 			Password: requireEnv("REDIS_PASSWORD"),
 			DB:       getIntEnv("REDIS_DB"),
 		},
-		Smtp:         smtpCfg,
-		AppPort:      requireEnv("APP_PORT"),
-		DatabaseUrl:  requireEnv("POSTGRES_URL"),
-		AppPublicUrl: requireEnv("APP_PUBLIC_URL"),
+		Smtp:            smtpCfg,
+		AppPort:         requireEnv("APP_PORT"),
+		DatabaseUrl:     requireEnv("POSTGRES_URL"),
+		AppPublicUrl:    requireEnv("APP_PUBLIC_URL"),
+		RolePermissions: getRolePermissionsEnv("ROLE_PERMISSIONS"),
+		Jobs: JobsConfig{
+			SnapshotInterval: time.Duration(getIntEnvOrDefault("BURNDOWN_SNAPSHOT_INTERVAL_MINUTES", 60)) * time.Minute,
+		},
+		Reports: ReportsConfig{
+			Workers:          getIntEnvOrDefault("REPORT_JOB_WORKERS", 2),
+			ResultTTL:        time.Duration(getIntEnvOrDefault("REPORT_JOB_TTL_MINUTES", 1440)) * time.Minute,
+			RecipientDomains: getListEnv("REPORT_RECIPIENT_DOMAINS"),
+			StorageDir:       getEnvOrDefault("REPORT_STORAGE_DIR", filepath.Join(os.TempDir(), "bug-tracker-reports")),
+		},
+		Notifications: NotificationsConfig{
+			PollInterval: time.Duration(getIntEnvOrDefault("NOTIFICATION_POLL_INTERVAL_SECONDS", 15)) * time.Second,
+			MaxAttempts:  getIntEnvOrDefault("NOTIFICATION_MAX_ATTEMPTS", 8),
+			Channels:     channels,
+			Chat:         loadChatConfig(channels),
+		},
+		Webhooks: WebhooksConfig{
+			PollInterval: time.Duration(getIntEnvOrDefault("WEBHOOK_POLL_INTERVAL_SECONDS", 10)) * time.Second,
+			MaxAttempts:  getIntEnvOrDefault("WEBHOOK_MAX_ATTEMPTS", 8),
+			Timeout:      time.Duration(getIntEnvOrDefault("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		},
+		Realtime: RealtimeConfig{
+			Heartbeat:    time.Duration(getIntEnvOrDefault("SSE_HEARTBEAT_SECONDS", 25)) * time.Second,
+			HistoryLimit: getIntEnvOrDefault("SSE_HISTORY_LIMIT", 1000),
+		},
+	}
+}
+
+// getNotificationChannelsEnv drops "email" when SMTP is disabled instead of wiring a notifier that can't send.
+func getNotificationChannelsEnv(key string, smtpEnabled bool) []string {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		value = "email"
+	}
+
+	var channels []string
+	for _, channel := range strings.Split(value, ",") {
+		channel = strings.ToLower(strings.TrimSpace(channel))
+		switch channel {
+		case "":
+			continue
+		case "email":
+			if !smtpEnabled {
+				log.Println("SMTP is disabled, the email notification channel is skipped")
+				continue
+			}
+		case "mattermost", "slack", "telegram":
+		default:
+			log.Fatalf("%s contains unknown channel '%s', expected email, mattermost, slack or telegram", key, channel)
+		}
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+func loadChatConfig(channels []string) ChatConfig {
+	chat := ChatConfig{
+		TelegramAPIURL: getEnvOrDefault("TELEGRAM_API_URL", "https://api.telegram.org"),
+		Timeout:        time.Duration(getIntEnvOrDefault("CHAT_TIMEOUT_SECONDS", 10)) * time.Second,
+	}
+	if slices.Contains(channels, "mattermost") {
+		chat.MattermostWebhookURL = requireEnv("MATTERMOST_WEBHOOK_URL")
+	}
+	if slices.Contains(channels, "slack") {
+		chat.SlackWebhookURL = requireEnv("SLACK_WEBHOOK_URL")
+	}
+	if slices.Contains(channels, "telegram") {
+		chat.TelegramBotToken = requireEnv("TELEGRAM_BOT_TOKEN")
+	}
+	return chat
+}
+
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return defaultValue
 }
 
 func requireEnv(key string) string {
@@ -95,6 +239,13 @@ func getIntEnv(key string) int {
 	return value
 }
 
+func getIntEnvOrDefault(key string, defaultValue int) int {
+	if os.Getenv(key) == "" {
+		return defaultValue
+	}
+	return getIntEnv(key)
+}
+
 func getBoolEnv(key string) bool {
 	value := os.Getenv(key)
 	if value == "" {
@@ -106,3 +257,30 @@ func getBoolEnv(key string) bool {
 	}
 	return boolValue
 }
+
+func getRolePermissionsEnv(key string) map[string][]string {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultRolePermissions
+	}
+
+	rolePermissions := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		role, permissions, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			log.Fatalf("%s must look like 'role=perm1,perm2;role2=*', got: %v", key, entry)
+		}
+
+		rolePermissions[role] = []string{}
+		for _, permission := range strings.Split(permissions, ",") {
+			if permission = strings.TrimSpace(permission); permission != "" {
+				rolePermissions[role] = append(rolePermissions[role], permission)
+			}
+		}
+	}
+	return rolePermissions
+}
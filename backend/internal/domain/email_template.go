@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxEmailSubjectLength = 255
+	maxEmailBodyLength    = 64 * 1024
+)
+
+// EmailTemplate overrides the body and, when Subject is set, the subject of one email in one locale.
+type EmailTemplate struct {
+	BaseModel
+	Name      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_email_templates_name_locale"`
+	Locale    string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_email_templates_name_locale"`
+	Subject   string    `gorm:"type:varchar(255);not null;default:''"`
+	Body      string    `gorm:"type:text;not null"`
+	UpdatedBy string    `gorm:"type:uuid;not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+type EmailTemplateParams struct {
+	Name      string
+	Locale    string
+	Subject   string
+	Body      string
+	UpdatedBy string
+}
+
+func NewEmailTemplate(params *EmailTemplateParams) (*EmailTemplate, error) {
+	now := time.Now().UTC()
+	template := &EmailTemplate{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		Name:      params.Name,
+		Locale:    params.Locale,
+		Subject:   strings.TrimSpace(params.Subject),
+		Body:      params.Body,
+		UpdatedBy: params.UpdatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := template.validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (t *EmailTemplate) Update(subject, body, updatedBy string) error {
+	t.Subject = strings.TrimSpace(subject)
+	t.Body = body
+	t.UpdatedBy = updatedBy
+
+	if err := t.validate(); err != nil {
+		return err
+	}
+
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (t *EmailTemplate) validate() error {
+	switch {
+	case t.Name == "" || t.Locale == "":
+		return fmt.Errorf("%w: template name and locale are required", ErrValidation)
+	case t.UpdatedBy == "":
+		return fmt.Errorf("%w: updatedBy is empty", ErrValidation)
+	case strings.TrimSpace(t.Body) == "":
+		return fmt.Errorf("%w: body is required", ErrValidation)
+	case len(t.Body) > maxEmailBodyLength:
+		return fmt.Errorf("%w: body must not exceed %d bytes", ErrValidation, maxEmailBodyLength)
+	case utf8.RuneCountInString(t.Subject) > maxEmailSubjectLength:
+		return fmt.Errorf("%w: subject must not exceed %d characters", ErrValidation, maxEmailSubjectLength)
+	case strings.ContainsAny(t.Subject, "\r\n"):
+		return fmt.Errorf("%w: subject must be a single line", ErrValidation)
+	}
+	return nil
+}
@@ -2,18 +2,42 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 type Folder struct {
 	BaseModel
-	Name      string     `gorm:"type:VARCHAR(255);not null"`
-	CreatedBy string     `gorm:"type:uuid;not null"`
-	CreatedAt time.Time  `gorm:"type:timestamptz;not null"`
-	DeletedAt *time.Time `gorm:"type:timestamptz"`
-	Creator   *User      `gorm:"foreignKey:CreatedBy"`
+	Name             string     `gorm:"type:VARCHAR(255);not null"`
+	Description      string     `gorm:"type:text"`
+	ReleaseVersion   string     `gorm:"type:varchar(64)"`
+	PlannedStartDate *time.Time `gorm:"type:date"`
+	PlannedEndDate   *time.Time `gorm:"type:date"`
+	OwnerID          *string    `gorm:"type:uuid"`
+	ReportTemplateID *string    `gorm:"type:uuid"`
+	CreatedBy        string     `gorm:"type:uuid;not null"`
+	CreatedAt        time.Time  `gorm:"type:timestamptz;not null"`
+	DeletedAt        *time.Time `gorm:"type:timestamptz"`
+	Creator          *User      `gorm:"foreignKey:CreatedBy"`
+	Owner            *User      `gorm:"foreignKey:OwnerID"`
+}
+
+// DateChange sets a date, or clears it when Value is nil.
+type DateChange struct {
+	Value *time.Time
+}
+
+type UpdateFolderParams struct {
+	Name             *string
+	Description      *string
+	ReleaseVersion   *string
+	PlannedStartDate *DateChange
+	PlannedEndDate   *DateChange
+	OwnerID          *string
+	ReportTemplateID *string
 }
 
 func NewFolder(name, userId string) (*Folder, error) {
@@ -26,11 +50,60 @@ func NewFolder(name, userId string) (*Folder, error) {
 			ID: uuid.NewString(),
 		},
 		Name:      name,
+		OwnerID:   &userId,
 		CreatedBy: userId,
 		CreatedAt: time.Now().UTC(),
 	}, nil
 }
 
+func (f *Folder) Update(params *UpdateFolderParams) error {
+	if params.Name != nil {
+		f.Name = strings.TrimSpace(*params.Name)
+	}
+	if params.Description != nil {
+		f.Description = *params.Description
+	}
+	if params.ReleaseVersion != nil {
+		f.ReleaseVersion = strings.TrimSpace(*params.ReleaseVersion)
+	}
+	if params.PlannedStartDate != nil {
+		f.PlannedStartDate = params.PlannedStartDate.Value
+	}
+	if params.PlannedEndDate != nil {
+		f.PlannedEndDate = params.PlannedEndDate.Value
+	}
+	if params.OwnerID != nil {
+		f.OwnerID = params.OwnerID
+	}
+	if params.ReportTemplateID != nil {
+		f.ReportTemplateID = params.ReportTemplateID
+		if *params.ReportTemplateID == "" {
+			f.ReportTemplateID = nil
+		}
+	}
+
+	return f.validate()
+}
+
+func (f *Folder) validate() error {
+	switch {
+	case f.Name == "":
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	case utf8.RuneCountInString(f.Name) > 255:
+		return fmt.Errorf("%w: name must be at most 255 characters", ErrValidation)
+	case utf8.RuneCountInString(f.ReleaseVersion) > 64:
+		return fmt.Errorf("%w: releaseVersion must be at most 64 characters", ErrValidation)
+	case f.OwnerID != nil && *f.OwnerID == "":
+		return fmt.Errorf("%w: ownerId must not be empty", ErrValidation)
+	}
+
+	if f.PlannedStartDate != nil && f.PlannedEndDate != nil && f.PlannedEndDate.Before(*f.PlannedStartDate) {
+		return fmt.Errorf("%w: plannedEndDate cannot be before plannedStartDate", ErrValidation)
+	}
+
+	return nil
+}
+
 func (f *Folder) Delete() {
 	now := time.Now().UTC()
 	f.DeletedAt = &now
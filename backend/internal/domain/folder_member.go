@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FolderRole string
+
+const (
+	FolderOwner  FolderRole = "owner"
+	FolderEditor FolderRole = "editor"
+	FolderViewer FolderRole = "viewer"
+)
+
+type FolderMember struct {
+	BaseModel
+	FolderID  string     `gorm:"type:uuid;not null;uniqueIndex:idx_folder_members_folder_user"`
+	UserID    string     `gorm:"type:uuid;not null;uniqueIndex:idx_folder_members_folder_user;index"`
+	Role      FolderRole `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;not null"`
+	User      *User      `gorm:"foreignKey:UserID"`
+}
+
+func NewFolderMember(folderID, userID string, role FolderRole) (*FolderMember, error) {
+	if folderID == "" || userID == "" {
+		return nil, fmt.Errorf("%w: folder id or user id is empty", ErrValidation)
+	}
+	if err := role.isValid(); err != nil {
+		return nil, err
+	}
+
+	return &FolderMember{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		FolderID:  folderID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func (m *FolderMember) ChangeRole(role FolderRole) error {
+	if err := role.isValid(); err != nil {
+		return err
+	}
+	m.Role = role
+	return nil
+}
+
+func (fr FolderRole) isValid() error {
+	switch fr {
+	case FolderOwner, FolderEditor, FolderViewer:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown folder role '%s'", ErrValidation, fr)
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FolderSnapshot struct {
+	BaseModel
+	FolderID              string    `gorm:"type:uuid;not null;uniqueIndex:idx_folder_snapshots_folder_date"`
+	Date                  time.Time `gorm:"type:date;not null;uniqueIndex:idx_folder_snapshots_folder_date"`
+	NotCheckedCount       int64     `gorm:"not null;default:0"`
+	CheckedCount          int64     `gorm:"not null;default:0"`
+	PartiallyCheckedCount int64     `gorm:"not null;default:0"`
+	FailedCount           int64     `gorm:"not null;default:0"`
+	CreatedAt             time.Time `gorm:"type:timestamptz;not null"`
+}
+
+func NewFolderSnapshot(folderID string, date time.Time, counts map[CheckStatus]int64) *FolderSnapshot {
+	return &FolderSnapshot{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		FolderID:              folderID,
+		Date:                  date.UTC().Truncate(24 * time.Hour),
+		NotCheckedCount:       counts[NotChecked],
+		CheckedCount:          counts[Checked],
+		PartiallyCheckedCount: counts[PartiallyChecked],
+		FailedCount:           counts[Failed],
+		CreatedAt:             time.Now().UTC(),
+	}
+}
+
+func (s *FolderSnapshot) Counts() map[CheckStatus]int64 {
+	return map[CheckStatus]int64{
+		NotChecked:       s.NotCheckedCount,
+		Checked:          s.CheckedCount,
+		PartiallyChecked: s.PartiallyCheckedCount,
+		Failed:           s.FailedCount,
+	}
+}
+
+func (s *FolderSnapshot) TotalCount() int64 {
+	return s.NotCheckedCount + s.CheckedCount + s.PartiallyCheckedCount + s.FailedCount
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InboxItem struct {
+	BaseModel
+	UserID         string            `gorm:"type:uuid;not null;index:idx_inbox_items_user,priority:1"`
+	NotificationID string            `gorm:"type:uuid;not null;uniqueIndex"`
+	Event          NotificationEvent `gorm:"type:varchar(50);not null"`
+	TaskID         *string           `gorm:"type:uuid"`
+	FolderID       *string           `gorm:"type:uuid"`
+	Payload        string            `gorm:"type:jsonb;not null"`
+	Read           bool              `gorm:"not null;default:false;index:idx_inbox_items_user,priority:2"`
+	ReadAt         *time.Time        `gorm:"type:timestamptz"`
+	CreatedAt      time.Time         `gorm:"type:timestamptz;not null;index"`
+}
+
+func NewInboxItem(notification *Notification) (*InboxItem, error) {
+	payload, err := notification.DecodePayload()
+	if err != nil {
+		return nil, err
+	}
+
+	item := &InboxItem{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		UserID:         notification.RecipientID,
+		NotificationID: notification.ID,
+		Event:          notification.Event,
+		Payload:        notification.Payload,
+		CreatedAt:      notification.CreatedAt,
+	}
+	if len(payload.TaskIDs) == 1 {
+		item.TaskID = &payload.TaskIDs[0]
+	}
+	if payload.FolderID != "" {
+		item.FolderID = &payload.FolderID
+	}
+
+	return item, nil
+}
+
+func (i *InboxItem) DecodePayload() (*NotificationPayload, error) {
+	return (&Notification{Payload: i.Payload}).DecodePayload()
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MentionSource string
+
+const (
+	MentionInComment     MentionSource = "comment"
+	MentionInDescription MentionSource = "description"
+)
+
+const maxMentionsPerText = 20
+
+// MaxMentionedUsers caps how many users a task grants read access to through mentions.
+const MaxMentionedUsers = 10
+
+// mentionPattern matches "@First.Last" or "@First_Last" that is not part of an email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}]+(?:-[\p{L}\p{N}]+)*(?:[._][\p{L}\p{N}]+(?:-[\p{L}\p{N}]+)*)+)`)
+
+type TaskMention struct {
+	BaseModel
+	TaskID      string        `gorm:"type:uuid;not null;uniqueIndex:idx_task_mentions_unique,priority:1"`
+	UserID      string        `gorm:"type:uuid;not null;uniqueIndex:idx_task_mentions_unique,priority:2;index"`
+	Source      MentionSource `gorm:"type:varchar(20);not null;uniqueIndex:idx_task_mentions_unique,priority:3"`
+	MentionedBy string        `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time     `gorm:"type:timestamptz;not null"`
+}
+
+func NewTaskMention(taskID, userID, mentionedBy string, source MentionSource) (*TaskMention, error) {
+	if taskID == "" || userID == "" || mentionedBy == "" {
+		return nil, fmt.Errorf("%w: mention requires a task, a user and an author", ErrValidation)
+	}
+	if source != MentionInComment && source != MentionInDescription {
+		return nil, fmt.Errorf("%w: unknown mention source '%s'", ErrValidation, source)
+	}
+
+	return &TaskMention{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		TaskID:      taskID,
+		UserID:      userID,
+		Source:      source,
+		MentionedBy: mentionedBy,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// ParseMentions returns the lowercased full names mentioned in text, e.g. "ivan petrov" for "@Ivan.Petrov".
+func ParseMentions(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := strings.ToLower(strings.NewReplacer(".", " ", "_", " ").Replace(match[1]))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+		if len(names) == maxMentionsPerText {
+			break
+		}
+	}
+	return names
+}
+
+// NewMentions lists the names mentioned in current but not in previous, so edits don't mention anyone twice.
+func NewMentions(previous, current string) []string {
+	before := make(map[string]bool)
+	for _, name := range ParseMentions(previous) {
+		before[name] = true
+	}
+
+	var names []string
+	for _, name := range ParseMentions(current) {
+		if !before[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationEvent string
+
+const (
+	EventTaskAssigned    NotificationEvent = "task_assigned"
+	EventTasksAssigned   NotificationEvent = "tasks_assigned"
+	EventResultSubmitted NotificationEvent = "result_submitted"
+	EventTaskReassigned  NotificationEvent = "task_reassigned"
+	EventTaskUpdated     NotificationEvent = "task_updated"
+	EventTaskDeleted     NotificationEvent = "task_deleted"
+	EventFolderDeleted   NotificationEvent = "folder_deleted"
+	EventTaskMentioned   NotificationEvent = "task_mentioned"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationDead    NotificationStatus = "dead"
+	NotificationDigest  NotificationStatus = "digest"
+	NotificationSkipped NotificationStatus = "skipped"
+)
+
+const (
+	retryBaseBackoff = 30 * time.Second
+	retryMaxBackoff  = time.Hour
+)
+
+// NotificationPayload keeps a snapshot of deleted entities, since they can't be loaded at delivery time.
+type NotificationPayload struct {
+	FolderID           string   `json:"folderId,omitempty"`
+	FolderName         string   `json:"folderName,omitempty"`
+	TaskIDs            []string `json:"taskIds,omitempty"`
+	SoftName           string   `json:"softName,omitempty"`
+	RequestID          string   `json:"requestId,omitempty"`
+	TaskCount          int      `json:"taskCount,omitempty"`
+	PreviousAssigneeID string   `json:"previousAssigneeId,omitempty"`
+	ChangedFields      []string `json:"changedFields,omitempty"`
+	ActorName          string   `json:"actorName,omitempty"`
+	MentionSource      string   `json:"mentionSource,omitempty"`
+}
+
+type Notification struct {
+	BaseModel
+	Event             NotificationEvent  `gorm:"type:varchar(50);not null"`
+	RecipientID       string             `gorm:"type:uuid;not null;index"`
+	Payload           string             `gorm:"type:jsonb;not null"`
+	Status            NotificationStatus `gorm:"type:varchar(20);not null;index:idx_notifications_due,priority:1"`
+	Attempts          int                `gorm:"not null;default:0"`
+	NextAttemptAt     time.Time          `gorm:"type:timestamptz;not null;index:idx_notifications_due,priority:2"`
+	LastError         string             `gorm:"type:text"`
+	DeliveredChannels []string           `gorm:"type:jsonb;serializer:json"`
+	SentAt            *time.Time         `gorm:"type:timestamptz"`
+	CreatedAt         time.Time          `gorm:"type:timestamptz;not null"`
+	UpdatedAt         time.Time          `gorm:"type:timestamptz;not null"`
+}
+
+func NewNotification(event NotificationEvent, recipientID string, payload *NotificationPayload) (*Notification, error) {
+	if recipientID == "" {
+		return nil, fmt.Errorf("%w: recipientId is empty", ErrValidation)
+	}
+	switch event {
+	case EventTaskAssigned, EventResultSubmitted, EventTaskReassigned:
+		if len(payload.TaskIDs) != 1 {
+			return nil, fmt.Errorf("%w: %s notification requires exactly one task", ErrValidation, event)
+		}
+	case EventTaskUpdated:
+		if len(payload.TaskIDs) != 1 || len(payload.ChangedFields) == 0 {
+			return nil, fmt.Errorf("%w: %s notification requires a task and changed fields", ErrValidation, event)
+		}
+	case EventTaskMentioned:
+		if len(payload.TaskIDs) != 1 || payload.MentionSource == "" {
+			return nil, fmt.Errorf("%w: %s notification requires a task and a mention source", ErrValidation, event)
+		}
+	case EventTasksAssigned:
+		if payload.FolderID == "" || len(payload.TaskIDs) == 0 {
+			return nil, fmt.Errorf("%w: %s notification requires a folder and tasks", ErrValidation, event)
+		}
+	case EventTaskDeleted:
+		if payload.SoftName == "" {
+			return nil, fmt.Errorf("%w: %s notification requires a task snapshot", ErrValidation, event)
+		}
+	case EventFolderDeleted:
+		if payload.FolderName == "" {
+			return nil, fmt.Errorf("%w: %s notification requires a folder snapshot", ErrValidation, event)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown notification event '%s'", ErrValidation, event)
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification payload: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return &Notification{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		Event:         event,
+		RecipientID:   recipientID,
+		Payload:       string(encoded),
+		Status:        NotificationPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+func (n *Notification) DecodePayload() (*NotificationPayload, error) {
+	var payload NotificationPayload
+	if err := json.Unmarshal([]byte(n.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode notification payload: %w", err)
+	}
+	return &payload, nil
+}
+
+func (n *Notification) MarkDelivered(channels ...string) {
+	for _, channel := range channels {
+		if !slices.Contains(n.DeliveredChannels, channel) {
+			n.DeliveredChannels = append(n.DeliveredChannels, channel)
+		}
+	}
+}
+
+func (n *Notification) MarkSent(now time.Time) {
+	n.Status = NotificationSent
+	n.LastError = ""
+	n.SentAt = &now
+	n.UpdatedAt = now
+}
+
+// MarkFailed retries with backoff until maxAttempts, then moves the notification to dead letters.
+func (n *Notification) MarkFailed(reason string, now time.Time, maxAttempts int) {
+	n.LastError = reason
+	n.UpdatedAt = now
+	if n.Attempts >= maxAttempts {
+		n.Status = NotificationDead
+		return
+	}
+
+	n.Status = NotificationPending
+	n.NextAttemptAt = now.Add(retryBackoff(n.Attempts))
+}
+
+func retryBackoff(attempts int) time.Duration {
+	backoff := retryBaseBackoff
+	for i := 1; i < attempts && backoff < retryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, retryMaxBackoff)
+}
+
+func (n *Notification) MarkDead(reason string, now time.Time) {
+	n.Status = NotificationDead
+	n.LastError = reason
+	n.UpdatedAt = now
+}
+
+func (n *Notification) HoldForDigest(now time.Time) {
+	n.Status = NotificationDigest
+	n.NextAttemptAt = now
+	n.UpdatedAt = now
+}
+
+func (n *Notification) MarkSkipped(now time.Time) {
+	n.Status = NotificationSkipped
+	n.UpdatedAt = now
+}
+
+// Postpone delays delivery without counting the claim as a failed attempt.
+func (n *Notification) Postpone(until, now time.Time) {
+	n.Status = NotificationPending
+	n.Attempts = max(n.Attempts-1, 0)
+	n.NextAttemptAt = until
+	n.UpdatedAt = now
+}
+
+func (n *Notification) Requeue(now time.Time) error {
+	if n.Status != NotificationDead {
+		return fmt.Errorf("%w: notification is not in the dead-letter list", ErrValidation)
+	}
+
+	n.Status = NotificationPending
+	n.Attempts = 0
+	n.NextAttemptAt = now
+	n.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type DeliveryMode string
+
+const (
+	DeliveryImmediate DeliveryMode = "immediate"
+	DeliveryDigest    DeliveryMode = "digest"
+	DeliveryOff       DeliveryMode = "off"
+)
+
+const (
+	defaultDigestHour = 9
+	quietHoursLayout  = "15:04"
+)
+
+var AllNotificationEvents = []NotificationEvent{
+	EventTaskAssigned,
+	EventTasksAssigned,
+	EventResultSubmitted,
+	EventTaskReassigned,
+	EventTaskUpdated,
+	EventTaskDeleted,
+	EventFolderDeleted,
+	EventTaskMentioned,
+}
+
+type NotificationSettings struct {
+	UserID          string                             `gorm:"type:uuid;primaryKey"`
+	Modes           map[NotificationEvent]DeliveryMode `gorm:"type:jsonb;serializer:json;not null"`
+	QuietHoursStart string                             `gorm:"type:varchar(5);not null;default:''"`
+	QuietHoursEnd   string                             `gorm:"type:varchar(5);not null;default:''"`
+	Timezone        string                             `gorm:"type:varchar(64);not null"`
+	DigestHour      int                                `gorm:"not null"`
+	LastDigestAt    *time.Time                         `gorm:"type:timestamptz"`
+	UpdatedAt       time.Time                          `gorm:"type:timestamptz;not null"`
+}
+
+type UpdateNotificationSettingsParams struct {
+	Modes           map[NotificationEvent]DeliveryMode
+	QuietHoursStart *string
+	QuietHoursEnd   *string
+	Timezone        *string
+	DigestHour      *int
+}
+
+func DefaultNotificationSettings(userID string) *NotificationSettings {
+	return &NotificationSettings{
+		UserID:     userID,
+		Modes:      map[NotificationEvent]DeliveryMode{},
+		Timezone:   "UTC",
+		DigestHour: defaultDigestHour,
+	}
+}
+
+func (s *NotificationSettings) Update(params *UpdateNotificationSettingsParams) error {
+	if s.Modes == nil {
+		s.Modes = map[NotificationEvent]DeliveryMode{}
+	}
+	for event, mode := range params.Modes {
+		if !slices.Contains(AllNotificationEvents, event) {
+			return fmt.Errorf("%w: unknown notification event '%s'", ErrValidation, event)
+		}
+		switch mode {
+		case DeliveryImmediate:
+			delete(s.Modes, event)
+		case DeliveryDigest, DeliveryOff:
+			s.Modes[event] = mode
+		default:
+			return fmt.Errorf("%w: unknown delivery mode '%s'", ErrValidation, mode)
+		}
+	}
+	if params.QuietHoursStart != nil {
+		s.QuietHoursStart = strings.TrimSpace(*params.QuietHoursStart)
+	}
+	if params.QuietHoursEnd != nil {
+		s.QuietHoursEnd = strings.TrimSpace(*params.QuietHoursEnd)
+	}
+	if params.Timezone != nil {
+		s.Timezone = strings.TrimSpace(*params.Timezone)
+	}
+	if params.DigestHour != nil {
+		s.DigestHour = *params.DigestHour
+	}
+
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	s.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (s *NotificationSettings) Mode(event NotificationEvent) DeliveryMode {
+	if mode, ok := s.Modes[event]; ok {
+		return mode
+	}
+	return DeliveryImmediate
+}
+
+// QuietUntil reports whether now falls into the quiet hours, which may cross midnight, and when they end.
+func (s *NotificationSettings) QuietUntil(now time.Time) (time.Time, bool) {
+	if s.QuietHoursStart == "" || s.QuietHoursEnd == "" {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	local := now.In(location)
+
+	start, _ := time.Parse(quietHoursLayout, s.QuietHoursStart)
+	end, _ := time.Parse(quietHoursLayout, s.QuietHoursEnd)
+	startAt := time.Date(local.Year(), local.Month(), local.Day(), start.Hour(), start.Minute(), 0, 0, location)
+	endAt := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, location)
+
+	if !endAt.After(startAt) {
+		if local.Before(endAt) {
+			return endAt.UTC(), true
+		}
+		endAt = endAt.AddDate(0, 0, 1)
+	}
+	if !local.Before(startAt) && local.Before(endAt) {
+		return endAt.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// DigestDue reports whether today's digest hour has passed and no digest was sent since then.
+func (s *NotificationSettings) DigestDue(now time.Time) bool {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+
+	digestAt := time.Date(local.Year(), local.Month(), local.Day(), s.DigestHour, 0, 0, 0, location)
+	if local.Before(digestAt) {
+		return false
+	}
+	return s.LastDigestAt == nil || s.LastDigestAt.Before(digestAt)
+}
+
+func (s *NotificationSettings) RecordDigest(now time.Time) {
+	s.LastDigestAt = &now
+	s.UpdatedAt = now
+}
+
+func (s *NotificationSettings) validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "" {
+		return fmt.Errorf("%w: unknown timezone '%s'", ErrValidation, s.Timezone)
+	}
+	if s.DigestHour < 0 || s.DigestHour > 23 {
+		return fmt.Errorf("%w: digestHour must be between 0 and 23", ErrValidation)
+	}
+	if (s.QuietHoursStart == "") != (s.QuietHoursEnd == "") {
+		return fmt.Errorf("%w: quiet hours require both start and end", ErrValidation)
+	}
+	if s.QuietHoursStart != "" && s.QuietHoursStart == s.QuietHoursEnd {
+		return fmt.Errorf("%w: quiet hours must not start and end at the same time", ErrValidation)
+	}
+	for _, value := range []string{s.QuietHoursStart, s.QuietHoursEnd} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(quietHoursLayout, value); err != nil {
+			return fmt.Errorf("%w: invalid quiet hours time '%s', expected HH:MM", ErrValidation, value)
+		}
+	}
+	return nil
+}
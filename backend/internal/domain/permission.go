@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Permission string
+
+const (
+	PermFolderCreate    Permission = "folder:create"
+	PermFolderRead      Permission = "folder:read:any"
+	PermFolderUpdate    Permission = "folder:update:any"
+	PermFolderDelete    Permission = "folder:delete"
+	PermFolderMembers   Permission = "folder:members:manage"
+	PermTaskCreate      Permission = "task:create"
+	PermTaskRead        Permission = "task:read:any"
+	PermTaskUpdate      Permission = "task:update:any"
+	PermTaskDelete      Permission = "task:delete:any"
+	PermTaskReview      Permission = "task:review"
+	PermReportDownload  Permission = "report:download"
+	PermReportTemplates Permission = "report:templates:manage"
+	PermUserList        Permission = "user:list"
+	PermAnalyticsRead   Permission = "analytics:read"
+	PermNotifications   Permission = "notification:manage"
+	PermWebhooks        Permission = "webhook:manage"
+	PermEmailTemplates  Permission = "email:templates:manage"
+)
+
+var AllPermissions = []Permission{
+	PermFolderCreate,
+	PermFolderRead,
+	PermFolderUpdate,
+	PermFolderDelete,
+	PermFolderMembers,
+	PermTaskCreate,
+	PermTaskRead,
+	PermTaskUpdate,
+	PermTaskDelete,
+	PermTaskReview,
+	PermReportDownload,
+	PermReportTemplates,
+	PermUserList,
+	PermAnalyticsRead,
+	PermNotifications,
+	PermWebhooks,
+	PermEmailTemplates,
+}
+
+var folderRolePermissions = map[FolderRole][]Permission{
+	FolderViewer: {
+		PermFolderRead,
+		PermTaskRead,
+	},
+	FolderEditor: {
+		PermFolderRead,
+		PermTaskRead,
+		PermTaskCreate,
+		PermTaskUpdate,
+		PermTaskDelete,
+		PermReportDownload,
+		PermUserList,
+	},
+	FolderOwner: {
+		PermFolderRead,
+		PermTaskRead,
+		PermTaskCreate,
+		PermTaskUpdate,
+		PermTaskDelete,
+		PermReportDownload,
+		PermUserList,
+		PermFolderUpdate,
+		PermFolderDelete,
+		PermFolderMembers,
+	},
+}
+
+func ParsePermission(s string) (Permission, error) {
+	for _, p := range AllPermissions {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown permission '%s'", ErrValidation, s)
+}
+
+func (fr FolderRole) Grants(permission Permission) bool {
+	for _, p := range folderRolePermissions[fr] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (fr FolderRole) Permissions() []Permission {
+	return slices.Clone(folderRolePermissions[fr])
+}
+
+func FolderRolesGranting(permission Permission) []FolderRole {
+	var roles []FolderRole
+	for _, role := range []FolderRole{FolderViewer, FolderEditor, FolderOwner} {
+		if role.Grants(permission) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
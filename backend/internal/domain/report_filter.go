@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+var ReportSortFields = []string{
+	"createdAt",
+	string(FieldSoftName),
+	string(FieldRequestID),
+	string(FieldTestEnvDateUpdate),
+	string(FieldCheckDate),
+	string(FieldCheckStatus),
+	string(FieldCheckResult),
+}
+
+// ReportFilter has no label filter: tasks have no labels yet, so that part of the request is left for later.
+type ReportFilter struct {
+	CheckStatuses   []string   `json:"checkStatuses,omitempty"`
+	CheckResults    []string   `json:"checkResults,omitempty"`
+	AssigneeIDs     []string   `json:"assigneeIds,omitempty"`
+	TestEnvDateFrom *time.Time `json:"testEnvDateFrom,omitempty"`
+	TestEnvDateTo   *time.Time `json:"testEnvDateTo,omitempty"`
+	CheckDateFrom   *time.Time `json:"checkDateFrom,omitempty"`
+	CheckDateTo     *time.Time `json:"checkDateTo,omitempty"`
+	Sort            string     `json:"sort,omitempty"`
+	Order           string     `json:"order,omitempty"`
+}
+
+func (f *ReportFilter) Validate() error {
+	for _, status := range f.CheckStatuses {
+		if err := CheckStatus(status).isValid(); err != nil {
+			return err
+		}
+	}
+	for _, result := range f.CheckResults {
+		if err := CheckResult(result).isValid(); err != nil {
+			return err
+		}
+	}
+
+	if f.TestEnvDateFrom != nil && f.TestEnvDateTo != nil && f.TestEnvDateTo.Before(*f.TestEnvDateFrom) {
+		return fmt.Errorf("%w: testEnvDateTo must not be before testEnvDateFrom", ErrValidation)
+	}
+	if f.CheckDateFrom != nil && f.CheckDateTo != nil && f.CheckDateTo.Before(*f.CheckDateFrom) {
+		return fmt.Errorf("%w: checkDateTo must not be before checkDateFrom", ErrValidation)
+	}
+
+	if f.Sort != "" && !f.isSortField(f.Sort) {
+		return fmt.Errorf("%w: unknown sort field '%s'", ErrValidation, f.Sort)
+	}
+	if f.Order != "" && f.Order != SortAsc && f.Order != SortDesc {
+		return fmt.Errorf("%w: order must be '%s' or '%s'", ErrValidation, SortAsc, SortDesc)
+	}
+
+	return nil
+}
+
+func (f *ReportFilter) isSortField(sort string) bool {
+	for _, field := range ReportSortFields {
+		if field == sort {
+			return true
+		}
+	}
+	return false
+}
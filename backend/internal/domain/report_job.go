@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportJobStatus string
+
+const (
+	ReportJobPending ReportJobStatus = "pending"
+	ReportJobRunning ReportJobStatus = "running"
+	ReportJobDone    ReportJobStatus = "done"
+	ReportJobFailed  ReportJobStatus = "failed"
+)
+
+type ReportJobKind string
+
+const (
+	ReportJobFolder  ReportJobKind = "folder"
+	ReportJobSummary ReportJobKind = "summary"
+)
+
+type ReportJobParams struct {
+	FolderIDs  []string      `json:"folderIds,omitempty"`
+	From       *time.Time    `json:"from,omitempty"`
+	To         *time.Time    `json:"to,omitempty"`
+	Format     string        `json:"format,omitempty"`
+	TemplateID string        `json:"templateId,omitempty"`
+	TaskFilter *ReportFilter `json:"taskFilter,omitempty"`
+	Locale     string        `json:"locale,omitempty"`
+}
+
+type ReportJob struct {
+	BaseModel
+	Kind        ReportJobKind   `gorm:"type:varchar(20);not null"`
+	Status      ReportJobStatus `gorm:"type:varchar(20);not null;index"`
+	Params      string          `gorm:"type:jsonb;not null"`
+	RequestedBy string          `gorm:"type:uuid;not null;index"`
+	Attempts    int             `gorm:"not null;default:0"`
+	FileName    string          `gorm:"type:varchar(255)"`
+	ContentType string          `gorm:"type:varchar(255)"`
+	FileKey     string          `gorm:"type:varchar(255)"`
+	FileSize    int64           `gorm:"not null;default:0"`
+	Error       string          `gorm:"type:text"`
+	CreatedAt   time.Time       `gorm:"type:timestamptz;not null"`
+	StartedAt   *time.Time      `gorm:"type:timestamptz"`
+	LeasedUntil *time.Time      `gorm:"type:timestamptz;index"`
+	FinishedAt  *time.Time      `gorm:"type:timestamptz"`
+	ExpiresAt   *time.Time      `gorm:"type:timestamptz;index"`
+}
+
+func NewReportJob(kind ReportJobKind, requestedBy string, params *ReportJobParams) (*ReportJob, error) {
+	if requestedBy == "" {
+		return nil, fmt.Errorf("%w: requestedBy is empty", ErrValidation)
+	}
+	switch kind {
+	case ReportJobFolder:
+		if len(params.FolderIDs) != 1 {
+			return nil, fmt.Errorf("%w: folder report job requires exactly one folder", ErrValidation)
+		}
+	case ReportJobSummary:
+	default:
+		return nil, fmt.Errorf("%w: unknown report job kind '%s'", ErrValidation, kind)
+	}
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report job params: %w", err)
+	}
+
+	return &ReportJob{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		Kind:        kind,
+		Status:      ReportJobPending,
+		Params:      string(encoded),
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+func (j *ReportJob) DecodeParams() (*ReportJobParams, error) {
+	var params ReportJobParams
+	if err := json.Unmarshal([]byte(j.Params), &params); err != nil {
+		return nil, fmt.Errorf("failed to decode report job params: %w", err)
+	}
+	return &params, nil
+}
+
+func (j *ReportJob) Complete(fileName, contentType, fileKey string, fileSize int64, ttl time.Duration) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+
+	j.Status = ReportJobDone
+	j.FileName = fileName
+	j.ContentType = contentType
+	j.FileKey = fileKey
+	j.FileSize = fileSize
+	j.Error = ""
+	j.LeasedUntil = nil
+	j.FinishedAt = &now
+	j.ExpiresAt = &expiresAt
+}
+
+func (j *ReportJob) Fail(reason string, ttl time.Duration) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+
+	j.Status = ReportJobFailed
+	j.FileKey = ""
+	j.FileSize = 0
+	j.Error = reason
+	j.LeasedUntil = nil
+	j.FinishedAt = &now
+	j.ExpiresAt = &expiresAt
+}
+
+func (j *ReportJob) IsFinished() bool {
+	return j.Status == ReportJobDone || j.Status == ReportJobFailed
+}
@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	maxScheduleRecipients = 50
+	minScheduleInterval   = time.Minute
+)
+
+type ReportScheduleRunStatus string
+
+const (
+	ScheduleRunSuccess ReportScheduleRunStatus = "success"
+	ScheduleRunFailed  ReportScheduleRunStatus = "failed"
+)
+
+type ReportSchedule struct {
+	BaseModel
+	Name       string        `gorm:"type:varchar(255);not null"`
+	Kind       ReportJobKind `gorm:"type:varchar(20);not null"`
+	FolderIDs  []string      `gorm:"type:jsonb;serializer:json;not null"`
+	Format     string        `gorm:"type:varchar(10)"`
+	Cron       string        `gorm:"type:varchar(100);not null"`
+	Timezone   string        `gorm:"type:varchar(64);not null"`
+	Recipients []string      `gorm:"type:jsonb;serializer:json;not null"`
+	TaskFilter *ReportFilter `gorm:"type:jsonb;serializer:json"`
+	Locale     string        `gorm:"type:varchar(8);not null;default:''"`
+	Enabled    bool          `gorm:"not null;default:true"`
+	CreatedBy  string        `gorm:"type:uuid;not null;index"`
+	NextRunAt  time.Time     `gorm:"type:timestamptz;not null;index"`
+	LastRunAt  *time.Time    `gorm:"type:timestamptz"`
+	LastError  string        `gorm:"type:text"`
+	CreatedAt  time.Time     `gorm:"type:timestamptz;not null"`
+	UpdatedAt  time.Time     `gorm:"type:timestamptz;not null"`
+}
+
+type ReportScheduleParams struct {
+	Name       string
+	Kind       ReportJobKind
+	FolderIDs  []string
+	Format     string
+	Cron       string
+	Timezone   string
+	Recipients []string
+	TaskFilter *ReportFilter
+	Locale     string
+	CreatedBy  string
+}
+
+type UpdateReportScheduleParams struct {
+	Name       *string
+	Format     *string
+	Cron       *string
+	Timezone   *string
+	Recipients []string
+	TaskFilter *ReportFilter
+	Enabled    *bool
+}
+
+type ReportScheduleRun struct {
+	BaseModel
+	ScheduleID string                  `gorm:"type:uuid;not null;index"`
+	Status     ReportScheduleRunStatus `gorm:"type:varchar(20);not null"`
+	FileName   string                  `gorm:"type:varchar(255)"`
+	Error      string                  `gorm:"type:text"`
+	StartedAt  time.Time               `gorm:"type:timestamptz;not null"`
+	FinishedAt time.Time               `gorm:"type:timestamptz;not null"`
+}
+
+func NewReportSchedule(params *ReportScheduleParams) (*ReportSchedule, error) {
+	if params.CreatedBy == "" {
+		return nil, fmt.Errorf("%w: createdBy is empty", ErrValidation)
+	}
+
+	now := time.Now().UTC()
+	schedule := &ReportSchedule{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		Name:       strings.TrimSpace(params.Name),
+		Kind:       params.Kind,
+		FolderIDs:  params.FolderIDs,
+		Format:     params.Format,
+		Cron:       strings.TrimSpace(params.Cron),
+		Timezone:   strings.TrimSpace(params.Timezone),
+		Recipients: normalizeRecipients(params.Recipients),
+		TaskFilter: params.TaskFilter,
+		Locale:     params.Locale,
+		Enabled:    true,
+		CreatedBy:  params.CreatedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+
+	if err := schedule.validate(); err != nil {
+		return nil, err
+	}
+	if err := schedule.ScheduleNext(now); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *ReportSchedule) Update(params *UpdateReportScheduleParams) error {
+	if params.Name != nil {
+		s.Name = strings.TrimSpace(*params.Name)
+	}
+	if params.Format != nil {
+		s.Format = *params.Format
+	}
+	if params.Cron != nil {
+		s.Cron = strings.TrimSpace(*params.Cron)
+	}
+	if params.Timezone != nil {
+		s.Timezone = strings.TrimSpace(*params.Timezone)
+	}
+	if params.Recipients != nil {
+		s.Recipients = normalizeRecipients(params.Recipients)
+	}
+	if params.TaskFilter != nil {
+		s.TaskFilter = params.TaskFilter
+	}
+	if params.Enabled != nil {
+		s.Enabled = *params.Enabled
+	}
+
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	s.UpdatedAt = time.Now().UTC()
+	return s.ScheduleNext(s.UpdatedAt)
+}
+
+func (s *ReportSchedule) ScheduleNext(after time.Time) error {
+	schedule, location, err := s.parse()
+	if err != nil {
+		return err
+	}
+
+	s.NextRunAt = schedule.Next(after.In(location)).UTC()
+	return nil
+}
+
+func (s *ReportSchedule) RecordRun(startedAt time.Time, fileName string, runErr error) *ReportScheduleRun {
+	now := time.Now().UTC()
+	run := &ReportScheduleRun{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		ScheduleID: s.ID,
+		Status:     ScheduleRunSuccess,
+		FileName:   fileName,
+		StartedAt:  startedAt,
+		FinishedAt: now,
+	}
+
+	s.LastRunAt = &startedAt
+	s.LastError = ""
+	if runErr != nil {
+		run.Status = ScheduleRunFailed
+		run.Error = runErr.Error()
+		s.LastError = runErr.Error()
+	}
+	s.UpdatedAt = now
+
+	return run
+}
+
+func (s *ReportSchedule) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if len([]rune(s.Name)) > 255 {
+		return fmt.Errorf("%w: name must not exceed 255 characters", ErrValidation)
+	}
+
+	switch s.Kind {
+	case ReportJobFolder:
+		if len(s.FolderIDs) != 1 {
+			return fmt.Errorf("%w: folder report schedule requires exactly one folder", ErrValidation)
+		}
+	case ReportJobSummary:
+		if len(s.FolderIDs) == 0 {
+			return fmt.Errorf("%w: summary report schedule requires at least one folder", ErrValidation)
+		}
+	default:
+		return fmt.Errorf("%w: unknown report kind '%s'", ErrValidation, s.Kind)
+	}
+
+	if len(s.Recipients) == 0 {
+		return fmt.Errorf("%w: at least one recipient is required", ErrValidation)
+	}
+	if len(s.Recipients) > maxScheduleRecipients {
+		return fmt.Errorf("%w: no more than %d recipients are allowed", ErrValidation, maxScheduleRecipients)
+	}
+	for _, recipient := range s.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("%w: invalid recipient '%s'", ErrValidation, recipient)
+		}
+	}
+
+	if s.TaskFilter != nil {
+		if err := s.TaskFilter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	_, _, err := s.parse()
+	return err
+}
+
+func (s *ReportSchedule) Disable(reason string) {
+	s.Enabled = false
+	s.LastError = reason
+	s.UpdatedAt = time.Now().UTC()
+}
+
+func (s *ReportSchedule) parse() (cron.Schedule, *time.Location, error) {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: unknown timezone '%s'", ErrValidation, s.Timezone)
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(s.Cron), "@every") {
+		return nil, nil, fmt.Errorf("%w: @every is not supported, use a cron expression", ErrValidation)
+	}
+	schedule, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid cron expression '%s': %v", ErrValidation, s.Cron, err)
+	}
+
+	first := schedule.Next(time.Now().In(location))
+	if schedule.Next(first).Sub(first) < minScheduleInterval {
+		return nil, nil, fmt.Errorf("%w: schedule must not run more than once a minute", ErrValidation)
+	}
+
+	return schedule, location, nil
+}
+
+func normalizeRecipients(recipients []string) []string {
+	seen := make(map[string]struct{}, len(recipients))
+	result := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		recipient = strings.TrimSpace(recipient)
+		key := strings.ToLower(recipient)
+		if recipient == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, recipient)
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type ReportField string
+
+const (
+	FieldSoftName          ReportField = "softName"
+	FieldRequestID         ReportField = "requestId"
+	FieldDescription       ReportField = "description"
+	FieldAssigneePerson    ReportField = "assigneePerson"
+	FieldTestEnvDateUpdate ReportField = "testEnvDateUpdate"
+	FieldCheckDate         ReportField = "checkDate"
+	FieldCheckStatus       ReportField = "checkStatus"
+	FieldCheckResult       ReportField = "checkResult"
+	FieldComment           ReportField = "comment"
+)
+
+var AllReportFields = []ReportField{
+	FieldSoftName,
+	FieldRequestID,
+	FieldDescription,
+	FieldAssigneePerson,
+	FieldTestEnvDateUpdate,
+	FieldCheckDate,
+	FieldCheckStatus,
+	FieldCheckResult,
+	FieldComment,
+}
+
+const (
+	DefaultReportDateFormat = "dd.mm.yyyy"
+	maxColumnWidth          = 255
+)
+
+var dateLayoutReplacer = strings.NewReplacer(
+	"yyyy", "2006",
+	"yy", "06",
+	"mm", "01",
+	"dd", "02",
+)
+
+// dateFormatTokens lists the date parts a format may use, longest first so "yyyy" wins over "yy".
+var dateFormatTokens = []string{"yyyy", "yy", "mm", "dd"}
+
+const dateFormatSeparators = ".-/ "
+
+type ReportColumn struct {
+	Field  ReportField `json:"field"`
+	Header string      `json:"header"`
+	Width  float64     `json:"width"`
+}
+
+type ReportTemplate struct {
+	BaseModel
+	Name       string         `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Columns    []ReportColumn `gorm:"type:jsonb;serializer:json;not null"`
+	DateFormat string         `gorm:"type:varchar(20);not null"`
+	IsDefault  bool           `gorm:"not null;default:false"`
+	CreatedAt  time.Time      `gorm:"type:timestamptz;not null"`
+	UpdatedAt  time.Time      `gorm:"type:timestamptz;not null"`
+}
+
+type UpdateReportTemplateParams struct {
+	Name       *string
+	Columns    []ReportColumn
+	DateFormat *string
+	IsDefault  *bool
+}
+
+func NewReportTemplate(name string, columns []ReportColumn, dateFormat string, isDefault bool) (*ReportTemplate, error) {
+	now := time.Now().UTC()
+	template := &ReportTemplate{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		Name:       strings.TrimSpace(name),
+		Columns:    columns,
+		DateFormat: strings.TrimSpace(dateFormat),
+		IsDefault:  isDefault,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if template.DateFormat == "" {
+		template.DateFormat = DefaultReportDateFormat
+	}
+
+	if err := template.validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (t *ReportTemplate) Update(params *UpdateReportTemplateParams) error {
+	if params.Name != nil {
+		t.Name = strings.TrimSpace(*params.Name)
+	}
+	if params.Columns != nil {
+		t.Columns = params.Columns
+	}
+	if params.DateFormat != nil {
+		t.DateFormat = strings.TrimSpace(*params.DateFormat)
+	}
+	if params.IsDefault != nil {
+		t.IsDefault = *params.IsDefault
+	}
+
+	t.UpdatedAt = time.Now().UTC()
+	return t.validate()
+}
+
+func (t *ReportTemplate) GoDateLayout() string {
+	return dateLayoutReplacer.Replace(t.DateFormat)
+}
+
+func (t *ReportTemplate) validate() error {
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	case utf8.RuneCountInString(t.Name) > 255:
+		return fmt.Errorf("%w: name must be at most 255 characters", ErrValidation)
+	case len(t.Columns) == 0:
+		return fmt.Errorf("%w: at least one column is required", ErrValidation)
+	}
+
+	seen := make(map[ReportField]bool, len(t.Columns))
+	for i := range t.Columns {
+		column := &t.Columns[i]
+		if !column.Field.isValid() {
+			return fmt.Errorf("%w: unknown report field '%s'", ErrValidation, column.Field)
+		}
+		if seen[column.Field] {
+			return fmt.Errorf("%w: field '%s' is used more than once", ErrValidation, column.Field)
+		}
+		seen[column.Field] = true
+
+		column.Header = strings.TrimSpace(column.Header)
+		if column.Header == "" {
+			return fmt.Errorf("%w: header for field '%s' is required", ErrValidation, column.Field)
+		}
+		if column.Width <= 0 || column.Width > maxColumnWidth {
+			return fmt.Errorf("%w: width for field '%s' must be between 0 and %d", ErrValidation, column.Field, maxColumnWidth)
+		}
+	}
+
+	return validateDateFormat(t.DateFormat)
+}
+
+func validateDateFormat(format string) error {
+	counts := make(map[byte]int, 3)
+	for rest := format; rest != ""; {
+		if strings.IndexByte(dateFormatSeparators, rest[0]) >= 0 {
+			rest = rest[1:]
+			continue
+		}
+
+		matched := false
+		for _, token := range dateFormatTokens {
+			if strings.HasPrefix(rest, token) {
+				counts[token[0]]++
+				rest = rest[len(token):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%w: dateFormat may only contain 'dd', 'mm', 'yy', 'yyyy' and '.', '-', '/', ' ' separators", ErrValidation)
+		}
+	}
+
+	if counts['d'] != 1 || counts['m'] != 1 || counts['y'] != 1 {
+		return fmt.Errorf("%w: dateFormat must contain day, month and year exactly once", ErrValidation)
+	}
+
+	return nil
+}
+
+func (f ReportField) isValid() bool {
+	for _, field := range AllReportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (f ReportField) IsDate() bool {
+	return f == FieldTestEnvDateUpdate || f == FieldCheckDate
+}
@@ -129,6 +129,18 @@ func NewTask(params *NewTaskParams) (*Task, error) {
 	return task, nil
 }
 
+func (t *Task) CloneTo(folderID, creatorID string, testEnvDateShift time.Duration) (*Task, error) {
+	return NewTask(&NewTaskParams{
+		SoftName:          t.SoftName,
+		RequestID:         t.RequestID,
+		Description:       t.Description,
+		AssigneeID:        t.AssigneeID,
+		CreatorID:         creatorID,
+		FolderID:          folderID,
+		TestEnvDateUpdate: t.TestEnvDateUpdate.Add(testEnvDateShift),
+	})
+}
+
 func (t *Task) Update(params *UpdateTaskParams) error {
 	if params.SoftName != nil {
 		t.SoftName = *params.SoftName
@@ -164,6 +176,46 @@ func (t *Task) Update(params *UpdateTaskParams) error {
 	return t.validate()
 }
 
+// ChangedFields lists the fields, except the assignee, that differ from previous.
+func (t *Task) ChangedFields(previous *Task) []string {
+	var fields []string
+	if t.SoftName != previous.SoftName {
+		fields = append(fields, "softName")
+	}
+	if t.RequestID != previous.RequestID {
+		fields = append(fields, "requestId")
+	}
+	if t.Description != previous.Description {
+		fields = append(fields, "description")
+	}
+	if !t.TestEnvDateUpdate.Equal(previous.TestEnvDateUpdate) {
+		fields = append(fields, "testEnvDateUpdate")
+	}
+	if t.FolderID != previous.FolderID {
+		fields = append(fields, "folderId")
+	}
+	if !sameTime(t.CheckDate, previous.CheckDate) {
+		fields = append(fields, "checkDate")
+	}
+	if t.CheckStatus != previous.CheckStatus {
+		fields = append(fields, "checkStatus")
+	}
+	if t.CheckResult != previous.CheckResult {
+		fields = append(fields, "checkResult")
+	}
+	if t.Comment != previous.Comment {
+		fields = append(fields, "comment")
+	}
+	return fields
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 func (cs CheckStatus) isValid() error {
 	switch cs {
 	case NotChecked, Checked, PartiallyChecked, Failed:
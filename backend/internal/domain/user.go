@@ -3,21 +3,47 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 )
 
 type User struct {
 	BaseModel
-	Email     string  `gorm:"type:varchar(255);unique;not null"`
-	FirstName string  `gorm:"type:varchar(32);not null"`
-	LastName  string  `gorm:"type:varchar(32);not null"`
-	Tasks     []*Task `gorm:"foreignKey:AssigneeID"`
+	Email     string   `gorm:"type:varchar(255);unique;not null"`
+	FirstName string   `gorm:"type:varchar(32);not null"`
+	LastName  string   `gorm:"type:varchar(32);not null"`
+	Roles     []string `gorm:"type:jsonb;serializer:json"`
+	Tasks     []*Task  `gorm:"foreignKey:AssigneeID"`
+
+	Locale          string                 `gorm:"type:varchar(8);not null;default:''"`
+	PreferredLocale string                 `gorm:"type:varchar(8);not null;default:''"`
+	ChatHandles     map[ChatChannel]string `gorm:"type:jsonb;serializer:json"`
 }
 
+type ChatChannel string
+
+const (
+	ChatMattermost ChatChannel = "mattermost"
+	ChatSlack      ChatChannel = "slack"
+	ChatTelegram   ChatChannel = "telegram"
+)
+
+var AllChatChannels = []ChatChannel{ChatMattermost, ChatSlack, ChatTelegram}
+
+const maxChatHandleLength = 64
+
+var (
+	// Telegram bots can only message a chat by its numeric id, not by username.
+	telegramChatIDPattern = regexp.MustCompile(`^-?[0-9]{1,20}$`)
+	// Slack only pings member ids, e.g. U024BE7LH, not display names.
+	slackMemberIDPattern = regexp.MustCompile(`^[UW][A-Z0-9]{2,}$`)
+)
+
 var ErrValidation = errors.New("validation error")
 
-func NewUser(userId, email, firstName, lastName string) (*User, error) {
+func NewUser(userId, email, firstName, lastName, locale string, roles []string) (*User, error) {
 	if userId == "" {
 		return nil, fmt.Errorf("%w: userId is required", ErrValidation)
 	}
@@ -38,9 +64,59 @@ func NewUser(userId, email, firstName, lastName string) (*User, error) {
 		Email:     email,
 		FirstName: capitalizeFirst(firstName),
 		LastName:  capitalizeFirst(lastName),
+		Roles:     roles,
+		Locale:    locale,
 	}, nil
 }
 
+func (u *User) FullName() string {
+	return u.FirstName + " " + u.LastName
+}
+
+func (u *User) EffectiveLocale() string {
+	if u.PreferredLocale != "" {
+		return u.PreferredLocale
+	}
+	return u.Locale
+}
+
+func (u *User) ChatHandle(channel ChatChannel) string {
+	return u.ChatHandles[channel]
+}
+
+func (u *User) SetChatHandle(channel ChatChannel, handle string) error {
+	if !slices.Contains(AllChatChannels, channel) {
+		return fmt.Errorf("%w: unknown chat channel '%s'", ErrValidation, channel)
+	}
+
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if len(handle) > maxChatHandleLength {
+		return fmt.Errorf("%w: chat handle must not exceed %d characters", ErrValidation, maxChatHandleLength)
+	}
+
+	if handle == "" {
+		delete(u.ChatHandles, channel)
+		return nil
+	}
+
+	switch channel {
+	case ChatTelegram:
+		if !telegramChatIDPattern.MatchString(handle) {
+			return fmt.Errorf("%w: telegram requires a numeric chat id", ErrValidation)
+		}
+	case ChatSlack:
+		if !slackMemberIDPattern.MatchString(handle) {
+			return fmt.Errorf("%w: slack requires a member id such as U024BE7LH", ErrValidation)
+		}
+	}
+
+	if u.ChatHandles == nil {
+		u.ChatHandles = map[ChatChannel]string{}
+	}
+	u.ChatHandles[channel] = handle
+	return nil
+}
+
 func capitalizeFirst(s string) string {
 	newString := strings.TrimSpace(s)
 
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookTaskCreated   WebhookEvent = "task.created"
+	WebhookTaskUpdated   WebhookEvent = "task.updated"
+	WebhookTaskDeleted   WebhookEvent = "task.deleted"
+	WebhookFolderCreated WebhookEvent = "folder.created"
+	WebhookFolderDeleted WebhookEvent = "folder.deleted"
+	WebhookPing          WebhookEvent = "ping"
+)
+
+var AllWebhookEvents = []WebhookEvent{
+	WebhookTaskCreated,
+	WebhookTaskUpdated,
+	WebhookTaskDeleted,
+	WebhookFolderCreated,
+	WebhookFolderDeleted,
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+const (
+	minWebhookSecretLength = 16
+	maxResponseBodyLength  = 1024
+)
+
+type Webhook struct {
+	BaseModel
+	Name      string         `gorm:"type:varchar(255);not null"`
+	URL       string         `gorm:"type:text;not null"`
+	Secret    string         `gorm:"type:varchar(255);not null"`
+	Events    []WebhookEvent `gorm:"type:jsonb;serializer:json;not null"`
+	FolderID  *string        `gorm:"type:uuid;index"`
+	Enabled   bool           `gorm:"not null;default:true"`
+	CreatedBy string         `gorm:"type:uuid;not null"`
+	CreatedAt time.Time      `gorm:"type:timestamptz;not null"`
+	UpdatedAt time.Time      `gorm:"type:timestamptz;not null"`
+}
+
+type WebhookParams struct {
+	Name      string
+	URL       string
+	Secret    string
+	Events    []WebhookEvent
+	FolderID  *string
+	CreatedBy string
+}
+
+type UpdateWebhookParams struct {
+	Name     *string
+	URL      *string
+	Secret   *string
+	Events   []WebhookEvent
+	FolderID *string
+	Enabled  *bool
+}
+
+type WebhookDelivery struct {
+	BaseModel
+	WebhookID      string                `gorm:"type:uuid;not null;index"`
+	Event          WebhookEvent          `gorm:"type:varchar(50);not null"`
+	Payload        string                `gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int                   `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `gorm:"type:timestamptz;not null;index:idx_webhook_deliveries_due,priority:2"`
+	ResponseStatus int                   `gorm:"not null;default:0"`
+	ResponseBody   string                `gorm:"type:text"`
+	LastError      string                `gorm:"type:text"`
+	DeliveredAt    *time.Time            `gorm:"type:timestamptz"`
+	CreatedAt      time.Time             `gorm:"type:timestamptz;not null;index"`
+	UpdatedAt      time.Time             `gorm:"type:timestamptz;not null"`
+}
+
+type webhookEnvelope struct {
+	ID        string       `json:"id"`
+	Event     WebhookEvent `json:"event"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Data      any          `json:"data"`
+}
+
+func NewWebhook(params *WebhookParams) (*Webhook, error) {
+	if params.CreatedBy == "" {
+		return nil, fmt.Errorf("%w: createdBy is empty", ErrValidation)
+	}
+
+	now := time.Now().UTC()
+	webhook := &Webhook{
+		BaseModel: BaseModel{
+			ID: uuid.NewString(),
+		},
+		Name:      strings.TrimSpace(params.Name),
+		URL:       strings.TrimSpace(params.URL),
+		Secret:    params.Secret,
+		Events:    params.Events,
+		FolderID:  params.FolderID,
+		Enabled:   true,
+		CreatedBy: params.CreatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := webhook.validate(); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (w *Webhook) Update(params *UpdateWebhookParams) error {
+	if params.Name != nil {
+		w.Name = strings.TrimSpace(*params.Name)
+	}
+	if params.URL != nil {
+		w.URL = strings.TrimSpace(*params.URL)
+	}
+	if params.Secret != nil {
+		w.Secret = *params.Secret
+	}
+	if params.Events != nil {
+		w.Events = params.Events
+	}
+	if params.FolderID != nil {
+		w.FolderID = params.FolderID
+		if *params.FolderID == "" {
+			w.FolderID = nil
+		}
+	}
+	if params.Enabled != nil {
+		w.Enabled = *params.Enabled
+	}
+
+	if err := w.validate(); err != nil {
+		return err
+	}
+
+	w.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (w *Webhook) validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if len([]rune(w.Name)) > 255 {
+		return fmt.Errorf("%w: name must not exceed 255 characters", ErrValidation)
+	}
+
+	target, err := url.Parse(w.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrValidation)
+	}
+
+	if len(w.Secret) < minWebhookSecretLength {
+		return fmt.Errorf("%w: secret must be at least %d characters long", ErrValidation, minWebhookSecretLength)
+	}
+
+	if len(w.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrValidation)
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(AllWebhookEvents, event) {
+			return fmt.Errorf("%w: unknown webhook event '%s'", ErrValidation, event)
+		}
+	}
+
+	return nil
+}
+
+// NewWebhookDelivery wraps data into the envelope that is sent and signed as is.
+func NewWebhookDelivery(webhookID string, event WebhookEvent, data any) (*WebhookDelivery, error) {
+	now := time.Now().UTC()
+	id := uuid.NewString()
+
+	encoded, err := json.Marshal(webhookEnvelope{
+		ID:        id,
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	return &WebhookDelivery{
+		BaseModel: BaseModel{
+			ID: id,
+		},
+		WebhookID:     webhookID,
+		Event:         event,
+		Payload:       string(encoded),
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+func (d *WebhookDelivery) MarkDelivered(responseStatus int, responseBody string, now time.Time) {
+	d.Status = WebhookDeliveryDelivered
+	d.ResponseStatus = responseStatus
+	d.ResponseBody = truncateResponseBody(responseBody)
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+func (d *WebhookDelivery) MarkFailed(responseStatus int, responseBody, reason string, now time.Time, maxAttempts int) {
+	d.ResponseStatus = responseStatus
+	d.ResponseBody = truncateResponseBody(responseBody)
+	d.LastError = reason
+	d.UpdatedAt = now
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = now.Add(retryBackoff(d.Attempts))
+}
+
+func truncateResponseBody(body string) string {
+	if len(body) <= maxResponseBodyLength {
+		return body
+	}
+	return strings.ToValidUTF8(body[:maxResponseBodyLength], "")
+}
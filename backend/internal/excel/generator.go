@@ -3,6 +3,7 @@ package excel
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
@@ -10,47 +11,50 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-var statusTranslations = map[domain.CheckStatus]string{
-	domain.NotChecked:       "не проверено",
-	domain.Checked:          "проверено",
-	domain.PartiallyChecked: "проверено частично",
-	domain.Failed:           "провалено",
+type reportGenerator struct {
 }
 
-var resultTranslations = map[domain.CheckResult]string{
-	domain.Success: "успешно",
-	domain.Failure: "неуспешно",
-	domain.Warning: "есть замечания",
+func (r *reportGenerator) Format() service.ReportFormat {
+	return service.FormatXLSX
 }
 
-type reportGenerator struct {
+func (r *reportGenerator) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (r *reportGenerator) Generate(layout *service.ReportLayout, meta *service.ReportMeta, tasks []*service.TaskReportRow) (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+	if err := r.Stream(buffer, layout, meta, service.SliceTaskRows(tasks)); err != nil {
+		return nil, err
+	}
+
+	return buffer, nil
 }
 
-func (r *reportGenerator) Generate(tasks []*service.TaskReportRow) (*bytes.Buffer, error) {
+func (r *reportGenerator) Stream(w io.Writer, layout *service.ReportLayout, meta *service.ReportMeta, tasks service.TaskRowSource) error {
 	file := excelize.NewFile()
 	defer file.Close()
 
-	sheetName := "Отчет по таскам"
+	sheetName := layout.T("report.sheet_name")
 	file.SetSheetName("Sheet1", sheetName)
 
-	styles, err := r.createStyles(file)
+	styles, err := r.createStyles(file, layout.DateFormat)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create styles: %w", err)
+		return fmt.Errorf("failed to create styles: %w", err)
 	}
 
-	r.setupSheet(file, sheetName, styles)
-
-	r.fillData(file, sheetName, tasks, styles)
+	if err := r.writeSheet(file, sheetName, layout, tasks, styles); err != nil {
+		return err
+	}
 
-	buffer := &bytes.Buffer{}
-	if err := file.Write(buffer); err != nil {
-		return nil, fmt.Errorf("failed to write in buffer: %w", err)
+	if err := file.Write(w); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
 	}
 
-	return buffer, nil
+	return nil
 }
 
-func (r *reportGenerator) createStyles(file *excelize.File) (map[string]int, error) {
+func (r *reportGenerator) createStyles(file *excelize.File, dateFormat string) (map[string]int, error) {
 	styles := make(map[string]int)
 
 	headerStyle, err := file.NewStyle(&excelize.Style{
@@ -88,7 +92,6 @@ func (r *reportGenerator) createStyles(file *excelize.File) (map[string]int, err
 	}
 	styles["default"] = defaultStyle
 
-	dateFormat := "dd.mm.yyyy"
 	dateStyle, err := file.NewStyle(&excelize.Style{
 		CustomNumFmt: &dateFormat,
 		Alignment: &excelize.Alignment{
@@ -141,72 +144,59 @@ func (r *reportGenerator) createStyles(file *excelize.File) (map[string]int, err
 	return styles, nil
 }
 
-func (r *reportGenerator) setupSheet(file *excelize.File, sheetName string, styles map[string]int) {
-	headers := []string{
-		"ПО",
-		"Номер заявки\nРазработчик/\nММ",
-		"Описание задачи",
-		"Ответственный от КБР",
-		"Дата\nобновления\nтестовой\nсреды",
-		"Дата\nпроверки\nФакт",
-		"Статус проверки",
-		"Результат\nпроверки",
-		"Комментарий к тестированию",
+func (r *reportGenerator) writeSheet(file *excelize.File, sheetName string, layout *service.ReportLayout, tasks service.TaskRowSource, styles map[string]int) error {
+	writer, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		return fmt.Errorf("failed to create stream writer: %w", err)
 	}
 
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		file.SetCellValue(sheetName, cell, header)
-		file.SetCellStyle(sheetName, cell, cell, styles["header"])
+	for i, width := range layout.Widths() {
+		if err := writer.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
 	}
 
-	file.SetRowHeight(sheetName, 1, 60)
-
-	columnWidths := []float64{25, 20, 40, 20, 20, 15, 15, 15, 35}
-	for i, width := range columnWidths {
-		col := string(rune('A' + i))
-		file.SetColWidth(sheetName, col, col, width)
+	headers := layout.Headers()
+	header := make([]interface{}, len(headers))
+	for i, value := range headers {
+		header[i] = excelize.Cell{StyleID: styles["header"], Value: value}
+	}
+	if err := writer.SetRow("A1", header, excelize.RowOpts{Height: 60}); err != nil {
+		return err
 	}
-}
 
-func (r *reportGenerator) fillData(file *excelize.File, sheetName string, tasks []*service.TaskReportRow, styles map[string]int) {
-	for i, task := range tasks {
-		row := i + 2
-		r.writeTaskRow(file, sheetName, row, task, styles)
-		file.SetRowHeight(sheetName, row, 40)
+	row := 2
+	err = tasks(func(task *service.TaskReportRow) error {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		row++
+		return writer.SetRow(cell, r.taskCells(layout, task, styles), excelize.RowOpts{Height: 40})
+	})
+	if err != nil {
+		return err
 	}
+
+	return writer.Flush()
 }
 
-func (r *reportGenerator) writeTaskRow(file *excelize.File, sheetName string, row int, task *service.TaskReportRow, styles map[string]int) {
-	data := []interface{}{
-		task.SoftName,
-		task.RequestID,
-		task.Description,
-		task.AssigneePerson,
-		task.TestEnvDateUpdate,
-		task.CheckDate,
-		r.getStatusDisplay(task.CheckStatus),
-		r.getResultDisplay(task.CheckResult),
-		task.Comment,
-	}
-
-	for col, value := range data {
-		cell, _ := excelize.CoordinatesToCellName(col+1, row)
+func (r *reportGenerator) taskCells(layout *service.ReportLayout, task *service.TaskReportRow, styles map[string]int) []interface{} {
+	cells := make([]interface{}, len(layout.Columns))
+	for col, column := range layout.Columns {
+		value := layout.Value(column.Field, task)
 		style := styles["default"]
 
-		switch col {
-		case 4, 5:
+		switch {
+		case column.Field.IsDate():
 			style = styles["date"]
 			if t, ok := value.(time.Time); ok && t.IsZero() {
 				value = nil
 			}
-		case 7:
+		case column.Field == domain.FieldCheckResult:
 			style = r.getResultStyle(task.CheckResult, styles)
 		}
 
-		file.SetCellValue(sheetName, cell, value)
-		file.SetCellStyle(sheetName, cell, cell, style)
+		cells[col] = excelize.Cell{StyleID: style, Value: value}
 	}
+	return cells
 }
 
 func (r *reportGenerator) createBorder() []excelize.Border {
@@ -218,13 +208,6 @@ func (r *reportGenerator) createBorder() []excelize.Border {
 	}
 }
 
-func (r *reportGenerator) getStatusDisplay(status domain.CheckStatus) string {
-	if translation, ok := statusTranslations[status]; ok {
-		return translation
-	}
-	return string(status)
-}
-
 func (r *reportGenerator) getResultStyle(result domain.CheckResult, styles map[string]int) int {
 	switch result {
 	case domain.Success:
@@ -238,16 +221,6 @@ func (r *reportGenerator) getResultStyle(result domain.CheckResult, styles map[s
 	}
 }
 
-func (r *reportGenerator) getResultDisplay(result domain.CheckResult) string {
-	if translation, ok := resultTranslations[result]; ok {
-		return translation
-	}
-	if result == "" {
-		return "-"
-	}
-	return string(result)
-}
-
 func NewReportGenerator() service.ReportGenerator {
 	return &reportGenerator{}
 }
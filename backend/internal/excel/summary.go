@@ -0,0 +1,221 @@
+package excel
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/xuri/excelize/v2"
+)
+
+const maxSheetNameLen = 31
+
+var summaryStatuses = []domain.CheckStatus{
+	domain.NotChecked,
+	domain.Checked,
+	domain.PartiallyChecked,
+	domain.Failed,
+}
+
+var statusColors = map[domain.CheckStatus]string{
+	domain.NotChecked:       "#9E9E9E",
+	domain.Checked:          "#4CAF50",
+	domain.PartiallyChecked: "#FF9800",
+	domain.Failed:           "#F44336",
+}
+
+var sheetNameReplacer = strings.NewReplacer(
+	":", " ",
+	"\\", " ",
+	"/", " ",
+	"?", " ",
+	"*", " ",
+	"[", "(",
+	"]", ")",
+	"'", "",
+)
+
+func (r *reportGenerator) GenerateSummary(layout *service.ReportLayout, sections []*service.ReportSection) (*bytes.Buffer, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	summarySheetName := layout.T("summary.sheet_name")
+	file.SetSheetName("Sheet1", summarySheetName)
+
+	styles, err := r.createStyles(file, layout.DateFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create styles: %w", err)
+	}
+
+	usedNames := map[string]bool{strings.ToLower(summarySheetName): true}
+	sheetNames := make([]string, len(sections))
+	for i, section := range sections {
+		sheetName := r.uniqueSheetName(section.Meta.FolderName, layout.T("summary.folder"), usedNames)
+		sheetNames[i] = sheetName
+
+		if _, err := file.NewSheet(sheetName); err != nil {
+			return nil, fmt.Errorf("failed to create sheet: %w", err)
+		}
+		if err := r.writeSheet(file, sheetName, layout, service.SliceTaskRows(section.Tasks), styles); err != nil {
+			return nil, err
+		}
+	}
+
+	r.fillSummary(file, summarySheetName, layout, sections, sheetNames, styles)
+
+	if err := r.addSummaryCharts(file, summarySheetName, layout, len(sections)); err != nil {
+		return nil, fmt.Errorf("failed to add charts: %w", err)
+	}
+
+	file.SetActiveSheet(0)
+
+	buffer := &bytes.Buffer{}
+	if err := file.Write(buffer); err != nil {
+		return nil, fmt.Errorf("failed to write in buffer: %w", err)
+	}
+
+	return buffer, nil
+}
+
+func (r *reportGenerator) fillSummary(file *excelize.File, summarySheetName string, layout *service.ReportLayout, sections []*service.ReportSection, sheetNames []string, styles map[string]int) {
+	headers := []string{layout.T("summary.folder"), layout.T("report.release_version"), layout.T("report.total_tasks")}
+	for _, status := range summaryStatuses {
+		headers = append(headers, layout.Status(status))
+	}
+	headers = append(headers, layout.T("summary.completed_percent"))
+
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		file.SetCellValue(summarySheetName, cell, header)
+		file.SetCellStyle(summarySheetName, cell, cell, styles["header"])
+	}
+	file.SetRowHeight(summarySheetName, 1, 40)
+
+	totals := make([]int, len(summaryStatuses))
+	for i, section := range sections {
+		row := i + 2
+		counts := make(map[domain.CheckStatus]int)
+		for _, task := range section.Tasks {
+			counts[task.CheckStatus]++
+		}
+
+		values := []interface{}{section.Meta.FolderName, section.Meta.ReleaseVersion, len(section.Tasks)}
+		for j, status := range summaryStatuses {
+			values = append(values, counts[status])
+			totals[j] += counts[status]
+		}
+		values = append(values, r.completionPercent(len(section.Tasks), counts[domain.NotChecked]))
+
+		r.writeSummaryRow(file, summarySheetName, row, values, styles["default"])
+
+		nameCell, _ := excelize.CoordinatesToCellName(1, row)
+		file.SetCellHyperLink(summarySheetName, nameCell, fmt.Sprintf("'%s'!A1", sheetNames[i]), "Location")
+	}
+
+	totalTasks := 0
+	for _, count := range totals {
+		totalTasks += count
+	}
+	values := []interface{}{layout.T("summary.total"), "", totalTasks}
+	for _, count := range totals {
+		values = append(values, count)
+	}
+	values = append(values, r.completionPercent(totalTasks, totals[0]))
+	r.writeSummaryRow(file, summarySheetName, len(sections)+2, values, styles["header"])
+
+	file.SetColWidth(summarySheetName, "A", "A", 35)
+	file.SetColWidth(summarySheetName, "B", "B", 18)
+	file.SetColWidth(summarySheetName, "C", "H", 15)
+}
+
+func (r *reportGenerator) writeSummaryRow(file *excelize.File, summarySheetName string, row int, values []interface{}, style int) {
+	for col, value := range values {
+		cell, _ := excelize.CoordinatesToCellName(col+1, row)
+		file.SetCellValue(summarySheetName, cell, value)
+		file.SetCellStyle(summarySheetName, cell, cell, style)
+	}
+}
+
+func (r *reportGenerator) addSummaryCharts(file *excelize.File, summarySheetName string, layout *service.ReportLayout, folderCount int) error {
+	const firstStatusCol = 4
+
+	lastRow := folderCount + 1
+	totalRow := folderCount + 2
+	sheetRef := fmt.Sprintf("'%s'", summarySheetName)
+
+	series := make([]excelize.ChartSeries, len(summaryStatuses))
+	for i, status := range summaryStatuses {
+		col, _ := excelize.ColumnNumberToName(firstStatusCol + i)
+		series[i] = excelize.ChartSeries{
+			Name:       fmt.Sprintf("%s!$%s$1", sheetRef, col),
+			Categories: fmt.Sprintf("%s!$A$2:$A$%d", sheetRef, lastRow),
+			Values:     fmt.Sprintf("%s!$%s$2:$%s$%d", sheetRef, col, col, lastRow),
+			Fill:       excelize.Fill{Type: "pattern", Color: []string{statusColors[status]}, Pattern: 1},
+		}
+	}
+
+	err := file.AddChart(summarySheetName, "J2", &excelize.Chart{
+		Type:      excelize.ColStacked,
+		Series:    series,
+		Title:     []excelize.RichTextRun{{Text: layout.T("summary.chart_by_folder")}},
+		Legend:    excelize.ChartLegend{Position: "bottom"},
+		Dimension: excelize.ChartDimension{Width: 640, Height: 360},
+	})
+	if err != nil {
+		return err
+	}
+
+	firstCol, _ := excelize.ColumnNumberToName(firstStatusCol)
+	lastCol, _ := excelize.ColumnNumberToName(firstStatusCol + len(summaryStatuses) - 1)
+	return file.AddChart(summarySheetName, "J22", &excelize.Chart{
+		Type: excelize.Pie,
+		Series: []excelize.ChartSeries{{
+			Name:       fmt.Sprintf("%s!$A$%d", sheetRef, totalRow),
+			Categories: fmt.Sprintf("%s!$%s$1:$%s$1", sheetRef, firstCol, lastCol),
+			Values:     fmt.Sprintf("%s!$%s$%d:$%s$%d", sheetRef, firstCol, totalRow, lastCol, totalRow),
+		}},
+		Title:     []excelize.RichTextRun{{Text: layout.T("summary.chart_total")}},
+		Legend:    excelize.ChartLegend{Position: "right"},
+		PlotArea:  excelize.ChartPlotArea{ShowPercent: true},
+		Dimension: excelize.ChartDimension{Width: 480, Height: 320},
+	})
+}
+
+func (r *reportGenerator) uniqueSheetName(name, fallback string, used map[string]bool) string {
+	base := strings.TrimSpace(sheetNameReplacer.Replace(name))
+	if base == "" {
+		base = fallback
+	}
+	base = truncateRunes(base, maxSheetNameLen)
+
+	candidate := base
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncateRunes(base, maxSheetNameLen-len([]rune(suffix))) + suffix
+	}
+
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+func (r *reportGenerator) completionPercent(total, notChecked int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(total-notChecked)/float64(total)*10000) / 100
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
+
+func NewSummaryGenerator() service.SummaryReportGenerator {
+	return &reportGenerator{}
+}
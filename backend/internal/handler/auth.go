@@ -23,7 +23,7 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	loginUrl, err := h.authService.PrepareLogin(r.Context())
 	if err != nil {
 		log.Printf("Error preparing login: %v", err)
-		http.Error(w, "Failed to prepare login", http.StatusInternalServerError)
+		httpMessage(w, r, "Failed to prepare login", http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -38,7 +38,7 @@ func (h *AuthHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	sessionId, err := h.authService.HandleCallback(r.Context(), code, state)
 	if err != nil {
 		log.Printf("Error in callback handler: %v", err)
-		http.Error(w, "Authentication failed", http.StatusUnauthorized)
+		httpMessage(w, r, "Authentication failed", http.StatusUnauthorized)
 	}
 
 	http.SetCookie(w, &http.Cookie{
@@ -61,14 +61,14 @@ func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
 			return
 		} else {
-			http.Error(w, "Invalid cookie", http.StatusBadRequest)
+			httpMessage(w, r, "Invalid cookie", http.StatusBadRequest)
 			return
 		}
 	}
 
 	logoutUrl, err := h.authService.PrepareLogout(r.Context(), sessionId.Value)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -4,6 +4,7 @@ type IdTokenClaims struct {
 	Email       string `json:"email"`
 	GivenName   string `json:"given_name"`
 	FamilyName  string `json:"family_name"`
+	Locale      string `json:"locale"`
 	RealmAccess struct {
 		Roles []string `json:"roles"`
 	} `json:"realm_access"`
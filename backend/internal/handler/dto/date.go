@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NullableDate tells an omitted field (Set is false) from an explicit null (Value is nil).
+type NullableDate struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (d *NullableDate) UnmarshalJSON(data []byte) error {
+	d.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		d.Value = nil
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if value, err := time.Parse(layout, raw); err == nil {
+			d.Value = &value
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date '%s', expected YYYY-MM-DD or RFC3339", raw)
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type UpdateEmailTemplateRequest struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type PreviewEmailTemplateRequest struct {
+	Subject *string `json:"subject"`
+	Body    *string `json:"body"`
+}
+
+type EmailTemplateSummary struct {
+	Name       string     `json:"name"`
+	Locale     string     `json:"locale"`
+	Overridden bool       `json:"overridden"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+}
+
+type EmailTemplateResponse struct {
+	Name       string     `json:"name"`
+	Locale     string     `json:"locale"`
+	Subject    string     `json:"subject"`
+	Body       string     `json:"body"`
+	Overridden bool       `json:"overridden"`
+	UpdatedBy  *string    `json:"updatedBy"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+}
+
+type EmailPreviewResponse struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
@@ -6,11 +6,37 @@ type CreateFolderRequest struct {
 	Name string
 }
 
+type UpdateFolderRequest struct {
+	Name             *string      `json:"name"`
+	Description      *string      `json:"description"`
+	ReleaseVersion   *string      `json:"releaseVersion"`
+	PlannedStartDate NullableDate `json:"plannedStartDate"`
+	PlannedEndDate   NullableDate `json:"plannedEndDate"`
+	OwnerID          *string      `json:"ownerId"`
+	ReportTemplateID *string      `json:"reportTemplateId"`
+}
+
+type CloneFolderRequest struct {
+	Name                 string   `json:"name"`
+	Description          *string  `json:"description"`
+	ReleaseVersion       *string  `json:"releaseVersion"`
+	CheckStatuses        []string `json:"checkStatuses"`
+	CheckResults         []string `json:"checkResults"`
+	AssigneeIDs          []string `json:"assigneeIds"`
+	TestEnvDateShiftDays int      `json:"testEnvDateShiftDays"`
+}
+
 type FolderDataResponse struct {
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	Id        string    `json:"id"`
-	TaskCount int       `json:"taskCount"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	ReleaseVersion   string     `json:"releaseVersion"`
+	PlannedStartDate *time.Time `json:"plannedStartDate"`
+	PlannedEndDate   *time.Time `json:"plannedEndDate"`
+	OwnerID          *string    `json:"ownerId"`
+	ReportTemplateID *string    `json:"reportTemplateId"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	Id               string     `json:"id"`
+	TaskCount        int        `json:"taskCount"`
 }
 
 type FolderCreatedResponse struct {
@@ -23,7 +49,30 @@ type FolderSearchResponse struct {
 }
 
 type FolderDetailsResponse struct {
-	Name           string    `json:"name"`
-	CreatedAt      time.Time `json:"createdAt"`
-	AssigneePerson string    `json:"assigneePerson"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	ReleaseVersion   string     `json:"releaseVersion"`
+	PlannedStartDate *time.Time `json:"plannedStartDate"`
+	PlannedEndDate   *time.Time `json:"plannedEndDate"`
+	OwnerID          *string    `json:"ownerId"`
+	OwnerPerson      string     `json:"ownerPerson"`
+	ReportTemplateID *string    `json:"reportTemplateId"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	AssigneePerson   string     `json:"assigneePerson"`
+}
+
+type AddFolderMemberRequest struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+}
+
+type UpdateFolderMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type FolderMemberResponse struct {
+	UserID    string    `json:"userId"`
+	FullName  string    `json:"fullName"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
 }
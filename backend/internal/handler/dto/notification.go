@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+type NotificationResponse struct {
+	ID            string     `json:"id"`
+	Event         string     `json:"event"`
+	RecipientID   string     `json:"recipientId"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"lastError"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt"`
+	SentAt        *time.Time `json:"sentAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+type NotificationListResponse struct {
+	Data       []*NotificationResponse `json:"data"`
+	Pagination PaginationResult        `json:"pagination"`
+}
+
+type InboxItemResponse struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	TaskID    *string    `json:"taskId"`
+	FolderID  *string    `json:"folderId"`
+	Message   string     `json:"message"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type InboxListResponse struct {
+	Data        []*InboxItemResponse `json:"data"`
+	UnreadCount int64                `json:"unreadCount"`
+	Pagination  PaginationResult     `json:"pagination"`
+}
+
+type UpdateNotificationSettingsRequest struct {
+	Events          map[string]string `json:"events"`
+	QuietHoursStart *string           `json:"quietHoursStart"`
+	QuietHoursEnd   *string           `json:"quietHoursEnd"`
+	Timezone        *string           `json:"timezone"`
+	DigestHour      *int              `json:"digestHour"`
+}
+
+type NotificationSettingsResponse struct {
+	Events          map[string]string `json:"events"`
+	QuietHoursStart string            `json:"quietHoursStart"`
+	QuietHoursEnd   string            `json:"quietHoursEnd"`
+	Timezone        string            `json:"timezone"`
+	DigestHour      int               `json:"digestHour"`
+	LastDigestAt    *time.Time        `json:"lastDigestAt"`
+}
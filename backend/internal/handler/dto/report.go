@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+type ReportTaskFilterRequest struct {
+	CheckStatuses   []string   `json:"checkStatuses"`
+	CheckResults    []string   `json:"checkResults"`
+	AssigneeIDs     []string   `json:"assigneeIds"`
+	TestEnvDateFrom *time.Time `json:"testEnvDateFrom"`
+	TestEnvDateTo   *time.Time `json:"testEnvDateTo"`
+	CheckDateFrom   *time.Time `json:"checkDateFrom"`
+	CheckDateTo     *time.Time `json:"checkDateTo"`
+	Sort            string     `json:"sort"`
+	Order           string     `json:"order"`
+}
+
+type CreateReportJobRequest struct {
+	Format     string                   `json:"format"`
+	TemplateID string                   `json:"templateId"`
+	Filter     *ReportTaskFilterRequest `json:"filter"`
+}
+
+type CreateSummaryReportJobRequest struct {
+	FolderIDs  []string                 `json:"folderIds"`
+	From       *time.Time               `json:"from"`
+	To         *time.Time               `json:"to"`
+	TemplateID string                   `json:"templateId"`
+	Filter     *ReportTaskFilterRequest `json:"filter"`
+}
+
+type ReportJobResponse struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Status      string     `json:"status"`
+	FileName    string     `json:"fileName,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	DownloadURL string     `json:"downloadUrl,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+type CreateReportScheduleRequest struct {
+	Name       string                   `json:"name"`
+	Kind       string                   `json:"kind"`
+	FolderIDs  []string                 `json:"folderIds"`
+	Format     string                   `json:"format"`
+	Cron       string                   `json:"cron"`
+	Timezone   string                   `json:"timezone"`
+	Recipients []string                 `json:"recipients"`
+	Filter     *ReportTaskFilterRequest `json:"filter"`
+}
+
+type UpdateReportScheduleRequest struct {
+	Name       *string                  `json:"name"`
+	Format     *string                  `json:"format"`
+	Cron       *string                  `json:"cron"`
+	Timezone   *string                  `json:"timezone"`
+	Recipients []string                 `json:"recipients"`
+	Filter     *ReportTaskFilterRequest `json:"filter"`
+	Enabled    *bool                    `json:"enabled"`
+}
+
+type ReportScheduleResponse struct {
+	ID         string                   `json:"id"`
+	Name       string                   `json:"name"`
+	Kind       string                   `json:"kind"`
+	FolderIDs  []string                 `json:"folderIds"`
+	Format     string                   `json:"format"`
+	Cron       string                   `json:"cron"`
+	Timezone   string                   `json:"timezone"`
+	Recipients []string                 `json:"recipients"`
+	Filter     *ReportTaskFilterRequest `json:"filter"`
+	Enabled    bool                     `json:"enabled"`
+	NextRunAt  time.Time                `json:"nextRunAt"`
+	LastRunAt  *time.Time               `json:"lastRunAt"`
+	LastError  string                   `json:"lastError"`
+	CreatedAt  time.Time                `json:"createdAt"`
+}
+
+type ReportScheduleRunResponse struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	FileName   string    `json:"fileName"`
+	Error      string    `json:"error"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+type ReportScheduleRunsResponse struct {
+	Data       []*ReportScheduleRunResponse `json:"data"`
+	Pagination PaginationResult             `json:"pagination"`
+}
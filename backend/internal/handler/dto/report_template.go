@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type ReportColumnDto struct {
+	Field  string  `json:"field"`
+	Header string  `json:"header"`
+	Width  float64 `json:"width"`
+}
+
+type CreateReportTemplateRequest struct {
+	Name       string            `json:"name"`
+	Columns    []ReportColumnDto `json:"columns"`
+	DateFormat string            `json:"dateFormat"`
+	IsDefault  bool              `json:"isDefault"`
+}
+
+type UpdateReportTemplateRequest struct {
+	Name       *string           `json:"name"`
+	Columns    []ReportColumnDto `json:"columns"`
+	DateFormat *string           `json:"dateFormat"`
+	IsDefault  *bool             `json:"isDefault"`
+}
+
+type ReportTemplateResponse struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Columns    []ReportColumnDto `json:"columns"`
+	DateFormat string            `json:"dateFormat"`
+	IsDefault  bool              `json:"isDefault"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+}
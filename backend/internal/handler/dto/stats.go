@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+type AssigneeStats struct {
+	UserID            string  `json:"userId"`
+	FullName          string  `json:"fullName"`
+	TotalCount        int64   `json:"totalCount"`
+	NotCheckedCount   int64   `json:"notCheckedCount"`
+	FailedCount       int64   `json:"failedCount"`
+	CompletionPercent float64 `json:"completionPercent"`
+}
+
+type OldestUncheckedTask struct {
+	ID        string    `json:"id"`
+	SoftName  string    `json:"softName"`
+	RequestID string    `json:"requestId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type FolderStatsResponse struct {
+	TotalCount          int64                `json:"totalCount"`
+	CompletionPercent   float64              `json:"completionPercent"`
+	StatusCounts        map[string]int64     `json:"statusCounts"`
+	ResultCounts        map[string]int64     `json:"resultCounts"`
+	Assignees           []*AssigneeStats     `json:"assignees"`
+	OldestUncheckedTask *OldestUncheckedTask `json:"oldestUncheckedTask"`
+	AvgTimeToCheckHours *float64             `json:"avgTimeToCheckHours"`
+}
+
+type BurndownPoint struct {
+	Date                  time.Time `json:"date"`
+	NotCheckedCount       int64     `json:"notCheckedCount"`
+	CheckedCount          int64     `json:"checkedCount"`
+	PartiallyCheckedCount int64     `json:"partiallyCheckedCount"`
+	FailedCount           int64     `json:"failedCount"`
+	TotalCount            int64     `json:"totalCount"`
+}
+
+type BurndownResponse struct {
+	From   time.Time        `json:"from"`
+	To     time.Time        `json:"to"`
+	Points []*BurndownPoint `json:"points"`
+}
+
+type TesterWorkload struct {
+	UserID                 string   `json:"userId"`
+	FullName               string   `json:"fullName"`
+	AssignedCount          int64    `json:"assignedCount"`
+	CheckedCount           int64    `json:"checkedCount"`
+	FailedCount            int64    `json:"failedCount"`
+	WarningCount           int64    `json:"warningCount"`
+	OpenCount              int64    `json:"openCount"`
+	MedianTimeToCheckHours *float64 `json:"medianTimeToCheckHours"`
+}
+
+type TeamWorkloadResponse struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	FolderID string            `json:"folderId,omitempty"`
+	Data     []*TesterWorkload `json:"data"`
+}
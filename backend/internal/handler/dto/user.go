@@ -12,10 +12,32 @@ type UserListResponse struct {
 	Pagination PaginationResult `json:"pagination"`
 }
 
+type FolderMembershipResponse struct {
+	FolderID    string
+	Role        string
+	Permissions []string
+}
+
 type UserInfoResponse struct {
-	FirstName string
-	LastName  string
-	IsAdmin   bool
+	FirstName   string
+	LastName    string
+	IsAdmin     bool
+	Permissions []string
+	Folders     []*FolderMembershipResponse
+	Locale      string
+
+	UnreadNotifications int64
+}
+
+type UpdateProfileRequest struct {
+	Locale      *string           `json:"locale"`
+	ChatHandles map[string]string `json:"chatHandles"`
+}
+
+type ProfileResponse struct {
+	Locale          string            `json:"locale"`
+	PreferredLocale string            `json:"preferredLocale"`
+	ChatHandles     map[string]string `json:"chatHandles"`
 }
 
 type UserStatsResponse struct {
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateWebhookRequest struct {
+	Name     string   `json:"name"`
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret"`
+	Events   []string `json:"events"`
+	FolderID *string  `json:"folderId"`
+}
+
+type UpdateWebhookRequest struct {
+	Name     *string  `json:"name"`
+	URL      *string  `json:"url"`
+	Secret   *string  `json:"secret"`
+	Events   []string `json:"events"`
+	FolderID *string  `json:"folderId"`
+	Enabled  *bool    `json:"enabled"`
+}
+
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	FolderID  *string   `json:"folderId"`
+	Enabled   bool      `json:"enabled"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type WebhookListResponse struct {
+	Data       []*WebhookResponse `json:"data"`
+	Pagination PaginationResult   `json:"pagination"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhookId"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	Payload        json.RawMessage `json:"payload"`
+	ResponseStatus int             `json:"responseStatus"`
+	ResponseBody   string          `json:"responseBody"`
+	LastError      string          `json:"lastError"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Data       []*WebhookDeliveryResponse `json:"data"`
+	Pagination PaginationResult           `json:"pagination"`
+}
+
+type WebhookTaskData struct {
+	ID                string     `json:"id"`
+	FolderID          string     `json:"folderId"`
+	SoftName          string     `json:"softName"`
+	RequestID         string     `json:"requestId"`
+	Description       string     `json:"description"`
+	AssigneeID        string     `json:"assigneeId"`
+	CreatorID         string     `json:"creatorId"`
+	TestEnvDateUpdate time.Time  `json:"testEnvDateUpdate"`
+	CheckDate         *time.Time `json:"checkDate"`
+	CheckStatus       string     `json:"checkStatus"`
+	CheckResult       string     `json:"checkResult"`
+	Comment           string     `json:"comment"`
+	ChangedFields     []string   `json:"changedFields,omitempty"`
+}
+
+type WebhookFolderData struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	ReleaseVersion string  `json:"releaseVersion"`
+	OwnerID        *string `json:"ownerId"`
+	TaskCount      int     `json:"taskCount"`
+}
+
+type WebhookPingData struct {
+	WebhookID string `json:"webhookId"`
+	Message   string `json:"message"`
+}
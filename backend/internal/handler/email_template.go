@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type EmailTemplateHandler struct {
+	templateService service.EmailTemplateService
+}
+
+func NewEmailTemplateHandler(templateService service.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{templateService: templateService}
+}
+
+func (h *EmailTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.List(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, templates)
+}
+
+func (h *EmailTemplateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	template, err := h.templateService.Get(r.Context(), chi.URLParam(r, "name"), chi.URLParam(r, "locale"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, template)
+}
+
+func (h *EmailTemplateHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.UpdateEmailTemplateRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	template, err := h.templateService.Update(r.Context(), &service.UpdateEmailTemplateParams{
+		Name:    chi.URLParam(r, "name"),
+		Locale:  chi.URLParam(r, "locale"),
+		Subject: request.Subject,
+		Body:    request.Body,
+		UserID:  userID,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, template)
+}
+
+func (h *EmailTemplateHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	if err := h.templateService.Reset(r.Context(), chi.URLParam(r, "name"), chi.URLParam(r, "locale")); err != nil {
+		h.writeError(w, r, err)
+	}
+}
+
+func (h *EmailTemplateHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.PreviewEmailTemplateRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &request) {
+		return
+	}
+
+	preview, err := h.templateService.Preview(r.Context(), &service.PreviewEmailTemplateParams{
+		Name:    chi.URLParam(r, "name"),
+		Locale:  chi.URLParam(r, "locale"),
+		Subject: request.Subject,
+		Body:    request.Body,
+		UserID:  userID,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, preview)
+}
+
+func (h *EmailTemplateHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, store.ErrEmailTemplateNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
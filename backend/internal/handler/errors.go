@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+var localizedErrors = []error{
+	domain.ErrValidation,
+	service.ErrAccessDenied,
+	service.ErrReportNotReady,
+	service.ErrUnsupportedFormat,
+	service.ErrNotAssignee,
+	service.ErrLastFolderOwner,
+	store.ErrSessionNotFound,
+	store.ErrStateNotFound,
+	store.ErrUserNotFound,
+	store.ErrTaskNotFound,
+	store.ErrFolderNotFound,
+	store.ErrMemberNotFound,
+	store.ErrReportJobNotFound,
+	store.ErrReportFileNotFound,
+	store.ErrScheduleNotFound,
+	store.ErrTemplateNotFound,
+	store.ErrTemplateNameTaken,
+	store.ErrNotificationNotFound,
+	store.ErrInboxItemNotFound,
+	store.ErrSettingsNotFound,
+	store.ErrWebhookNotFound,
+	store.ErrEmailTemplateNotFound,
+}
+
+func httpError(w http.ResponseWriter, r *http.Request, err error, status int) {
+	http.Error(w, i18n.LocalizeError(i18n.FromContext(r.Context()), err, localizedErrors...), status)
+}
+
+func httpMessage(w http.ResponseWriter, r *http.Request, message string, status int) {
+	http.Error(w, i18n.TranslateError(i18n.FromContext(r.Context()), message), status)
+}
@@ -1,15 +1,16 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/essentialkaos/translit/v3"
 	"github.com/go-chi/chi/v5"
 	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/handler/dto"
 	"github.com/pesos228/bug-tracker/internal/service"
 	"github.com/pesos228/bug-tracker/internal/store"
@@ -26,14 +27,13 @@ func NewFolderHandler(folderService service.FolderService, reportService service
 
 func (f *FolderHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var newFolderRequest dto.CreateFolderRequest
-	if err := json.NewDecoder(r.Body).Decode(&newFolderRequest); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to decode JSON: %s", err.Error()), http.StatusBadRequest)
+	if !decodeJSON(w, r, &newFolderRequest) {
 		return
 	}
 
 	userId, ok := appmw.UserIdFromContext(r.Context())
 	if !ok {
-		http.Error(w, "User id not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
 		return
 	}
 
@@ -47,6 +47,94 @@ func (f *FolderHandler) Create(w http.ResponseWriter, r *http.Request) {
 	encodeJSON(w, folder)
 }
 
+func (f *FolderHandler) Update(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	var folderUpdate dto.UpdateFolderRequest
+	if ok := decodeJSON(w, r, &folderUpdate); !ok {
+		return
+	}
+
+	err := f.folderService.Update(r.Context(), &service.UpdateFolderParams{
+		FolderID:         folderID,
+		Name:             folderUpdate.Name,
+		Description:      folderUpdate.Description,
+		ReleaseVersion:   folderUpdate.ReleaseVersion,
+		PlannedStartDate: mapNullableDate(folderUpdate.PlannedStartDate),
+		PlannedEndDate:   mapNullableDate(folderUpdate.PlannedEndDate),
+		OwnerID:          folderUpdate.OwnerID,
+		ReportTemplateID: folderUpdate.ReportTemplateID,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			httpError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, store.ErrFolderNotFound) || errors.Is(err, store.ErrUserNotFound) || errors.Is(err, store.ErrTemplateNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
+
+func mapNullableDate(date dto.NullableDate) *domain.DateChange {
+	if !date.Set {
+		return nil
+	}
+	return &domain.DateChange{Value: date.Value}
+}
+
+func (f *FolderHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	var cloneRequest dto.CloneFolderRequest
+	if ok := decodeJSON(w, r, &cloneRequest); !ok {
+		return
+	}
+
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	folder, err := f.folderService.Clone(r.Context(), &service.CloneFolderParams{
+		SourceFolderID:       folderID,
+		Name:                 cloneRequest.Name,
+		Description:          cloneRequest.Description,
+		ReleaseVersion:       cloneRequest.ReleaseVersion,
+		CheckStatuses:        cloneRequest.CheckStatuses,
+		CheckResults:         cloneRequest.CheckResults,
+		AssigneeIDs:          cloneRequest.AssigneeIDs,
+		TestEnvDateShiftDays: cloneRequest.TestEnvDateShiftDays,
+		CurrentUserID:        userID,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			httpError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, store.ErrFolderNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encodeJSON(w, folder)
+}
+
 func (f *FolderHandler) Search(w http.ResponseWriter, r *http.Request) {
 	query := getQueryString(r.URL.Query(), "query", "")
 	query = strings.TrimSpace(query)
@@ -54,7 +142,18 @@ func (f *FolderHandler) Search(w http.ResponseWriter, r *http.Request) {
 	page := getQueryInt(r.URL.Query(), "page", 1)
 	pageSize := getQueryInt(r.URL.Query(), "pageSize", 10)
 
-	result, err := f.folderService.Search(r.Context(), page, pageSize, query)
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := f.folderService.Search(r.Context(), &service.SearchFoldersParams{
+		Page:      page,
+		PageSize:  pageSize,
+		Query:     query,
+		Principal: principal,
+	})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Internal server error while searching: %s", err.Error()), http.StatusInternalServerError)
 		return
@@ -66,16 +165,22 @@ func (f *FolderHandler) Search(w http.ResponseWriter, r *http.Request) {
 func (f *FolderHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	folderID := chi.URLParam(r, "id")
 	if folderID == "" {
-		http.Error(w, "Folder id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
-	if err := f.folderService.Delete(r.Context(), folderID); err != nil {
+	if err := f.folderService.Delete(r.Context(), folderID, userID); err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 }
@@ -83,22 +188,120 @@ func (f *FolderHandler) Delete(w http.ResponseWriter, r *http.Request) {
 func (f *FolderHandler) Download(w http.ResponseWriter, r *http.Request) {
 	folderID := chi.URLParam(r, "id")
 	if folderID == "" {
-		http.Error(w, "Folder id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	format := service.ReportFormat(strings.ToLower(getQueryString(r.URL.Query(), "format", "")))
+	if format == "" {
+		negotiated, err := f.reportService.NegotiateFormat(r.Header.Get("Accept"))
+		if err != nil {
+			httpError(w, r, err, http.StatusNotAcceptable)
+			return
+		}
+		format = negotiated
+	}
+
+	taskFilter, err := getReportFilter(r.URL.Query())
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
-	report, err := f.reportService.Create(r.Context(), folderID)
+	report, err := f.reportService.Open(r.Context(), &service.CreateReportParams{
+		FolderID:   folderID,
+		Format:     format,
+		TemplateID: getQueryString(r.URL.Query(), "template", ""),
+		TaskFilter: taskFilter,
+	})
 	if err != nil {
-		if errors.Is(err, store.ErrFolderNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+		if errors.Is(err, service.ErrUnsupportedFormat) || errors.Is(err, domain.ErrValidation) {
+			httpError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, store.ErrFolderNotFound) || errors.Is(err, store.ErrTemplateNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	writer := &reportStreamWriter{w: w, report: report}
+	if err := report.Render(writer); err != nil {
+		if !writer.started {
+			httpError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		log.Printf("REPORT_ERROR: streaming report for folder %s was interrupted: %v", folderID, err)
+	}
+}
+
+type reportStreamWriter struct {
+	w       http.ResponseWriter
+	report  *service.ReportStream
+	started bool
+}
+
+func (s *reportStreamWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", s.report.ContentType)
+		s.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", translit.ICAO(s.report.FileName)))
+	}
+	return s.w.Write(p)
+}
+
+func (f *FolderHandler) DownloadSummary(w http.ResponseWriter, r *http.Request) {
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	folderIDs := getQueryList(query, "folderId")
+
+	from, err := getQueryDate(query, "from")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	to, err := getQueryDate(query, "to")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	taskFilter, err := getReportFilter(query)
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	report, err := f.reportService.CreateSummary(r.Context(), &service.SummaryReportParams{
+		FolderIDs:  folderIDs,
+		From:       from,
+		To:         to,
+		TemplateID: getQueryString(query, "template", ""),
+		TaskFilter: taskFilter,
+		Principal:  principal,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			httpError(w, r, err, http.StatusBadRequest)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		if errors.Is(err, store.ErrFolderNotFound) || errors.Is(err, store.ErrTemplateNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", translit.ICAO(report.FileName)))
+	w.Header().Set("Content-Type", report.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", report.FileName))
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", report.Data.Len()))
 
 	w.Write(report.Data.Bytes())
@@ -107,19 +310,113 @@ func (f *FolderHandler) Download(w http.ResponseWriter, r *http.Request) {
 func (f *FolderHandler) Details(w http.ResponseWriter, r *http.Request) {
 	folderID := chi.URLParam(r, "id")
 	if folderID == "" {
-		http.Error(w, "Folder id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
 		return
 	}
 
 	details, err := f.folderService.Details(r.Context(), folderID)
 	if err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
 	encodeJSON(w, details)
 }
+
+func (f *FolderHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	members, err := f.folderService.ListMembers(r.Context(), folderID)
+	if err != nil {
+		if errors.Is(err, store.ErrFolderNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	encodeJSON(w, members)
+}
+
+func (f *FolderHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	var memberRequest dto.AddFolderMemberRequest
+	if ok := decodeJSON(w, r, &memberRequest); !ok {
+		return
+	}
+
+	err := f.folderService.AddMember(r.Context(), &service.FolderMemberParams{
+		FolderID: folderID,
+		UserID:   memberRequest.UserID,
+		Role:     memberRequest.Role,
+	})
+	if err != nil {
+		writeMemberError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (f *FolderHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userId")
+	if folderID == "" || userID == "" {
+		httpMessage(w, r, "Folder id or user id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	var memberRequest dto.UpdateFolderMemberRequest
+	if ok := decodeJSON(w, r, &memberRequest); !ok {
+		return
+	}
+
+	err := f.folderService.UpdateMember(r.Context(), &service.FolderMemberParams{
+		FolderID: folderID,
+		UserID:   userID,
+		Role:     memberRequest.Role,
+	})
+	if err != nil {
+		writeMemberError(w, r, err)
+	}
+}
+
+func (f *FolderHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userId")
+	if folderID == "" || userID == "" {
+		httpMessage(w, r, "Folder id or user id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	if err := f.folderService.RemoveMember(r.Context(), folderID, userID); err != nil {
+		writeMemberError(w, r, err)
+	}
+}
+
+func writeMemberError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, store.ErrFolderNotFound), errors.Is(err, store.ErrUserNotFound), errors.Is(err, store.ErrMemberNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, service.ErrLastFolderOwner):
+		httpError(w, r, err, http.StatusConflict)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
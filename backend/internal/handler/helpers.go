@@ -6,6 +6,10 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/i18n"
 )
 
 func getQueryInt(query url.Values, key string, defaultValue int) int {
@@ -29,9 +33,35 @@ func getQueryString(query url.Values, key string, defaultValue string) string {
 	return str
 }
 
+func getQueryList(query url.Values, key string) []string {
+	var values []string
+	for _, value := range query[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+func getQueryDate(query url.Values, key string) (*time.Time, error) {
+	str := query.Get(key)
+	if str == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.DateOnly, str)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", key)
+	}
+	return &value, nil
+}
+
 func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
 	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to decode JSON: %s", err.Error()), http.StatusBadRequest)
+		message := i18n.TranslateError(i18n.FromContext(r.Context()), "Failed to decode JSON")
+		http.Error(w, fmt.Sprintf("%s: %s", message, err.Error()), http.StatusBadRequest)
 		return false
 	}
 	return true
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) ListInbox(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	inbox, err := h.notificationService.ListInbox(r.Context(), &service.ListInboxParams{
+		UserID:     userID,
+		UnreadOnly: getQueryString(r.URL.Query(), "unread", "") == "true",
+		Page:       getQueryInt(r.URL.Query(), "page", 1),
+		PageSize:   getQueryInt(r.URL.Query(), "pageSize", 10),
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, inbox)
+}
+
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	itemID := chi.URLParam(r, "id")
+	if itemID == "" {
+		httpMessage(w, r, "Notification id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.notificationService.MarkRead(r.Context(), userID, itemID); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+}
+
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(r.Context(), userID); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+}
+
+func (h *NotificationHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	settings, err := h.notificationService.GetSettings(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, settings)
+}
+
+func (h *NotificationHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.UpdateNotificationSettingsRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	settings, err := h.notificationService.UpdateSettings(r.Context(), &service.UpdateNotificationSettingsParams{
+		UserID:          userID,
+		Events:          request.Events,
+		QuietHoursStart: request.QuietHoursStart,
+		QuietHoursEnd:   request.QuietHoursEnd,
+		Timezone:        request.Timezone,
+		DigestHour:      request.DigestHour,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, settings)
+}
+
+func (h *NotificationHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	notifications, err := h.notificationService.ListDeadLetters(
+		r.Context(),
+		getQueryInt(r.URL.Query(), "page", 1),
+		getQueryInt(r.URL.Query(), "pageSize", 10),
+	)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, notifications)
+}
+
+func (h *NotificationHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	notificationID := chi.URLParam(r, "id")
+	if notificationID == "" {
+		httpMessage(w, r, "Notification id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	notification, err := h.notificationService.Retry(r.Context(), notificationID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, notification)
+}
+
+func (h *NotificationHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, store.ErrNotificationNotFound), errors.Is(err, store.ErrInboxItemNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
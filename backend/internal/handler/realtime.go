@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+const sseRetryDelay = 3 * time.Second
+
+type RealtimeHandler struct {
+	realtimeService service.RealtimeService
+	heartbeat       time.Duration
+}
+
+func NewRealtimeHandler(realtimeService service.RealtimeService, heartbeat time.Duration) *RealtimeHandler {
+	return &RealtimeHandler{
+		realtimeService: realtimeService,
+		heartbeat:       heartbeat,
+	}
+}
+
+func (h *RealtimeHandler) FolderEvents(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	h.stream(w, r, service.FolderTopic(folderID))
+}
+
+func (h *RealtimeHandler) UserEvents(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	h.stream(w, r, service.UserTopic(userID))
+}
+
+// stream falls back to the lastEventId query parameter for clients that can't send Last-Event-ID.
+func (h *RealtimeHandler) stream(w http.ResponseWriter, r *http.Request, topic string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httpMessage(w, r, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = getQueryString(r.URL.Query(), "lastEventId", "")
+	}
+
+	events, err := h.realtimeService.Subscribe(r.Context(), topic, lastEventID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetryDelay.Milliseconds())
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (h *RealtimeHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		httpError(w, r, err, http.StatusBadRequest)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+)
+
+func getReportFilter(query url.Values) (*domain.ReportFilter, error) {
+	filter := &domain.ReportFilter{
+		CheckStatuses: getQueryList(query, "checkStatus"),
+		CheckResults:  getQueryList(query, "checkResult"),
+		AssigneeIDs:   getQueryList(query, "assigneeId"),
+		Sort:          getQueryString(query, "sort", ""),
+		Order:         strings.ToLower(getQueryString(query, "order", "")),
+	}
+
+	dates := []struct {
+		key    string
+		target **time.Time
+	}{
+		{"testEnvDateFrom", &filter.TestEnvDateFrom},
+		{"testEnvDateTo", &filter.TestEnvDateTo},
+		{"checkDateFrom", &filter.CheckDateFrom},
+		{"checkDateTo", &filter.CheckDateTo},
+	}
+	for _, date := range dates {
+		value, err := getQueryDate(query, date.key)
+		if err != nil {
+			return nil, err
+		}
+		*date.target = value
+	}
+
+	return filter, nil
+}
+
+func mapReportFilterRequest(request *dto.ReportTaskFilterRequest) *domain.ReportFilter {
+	if request == nil {
+		return nil
+	}
+
+	return &domain.ReportFilter{
+		CheckStatuses:   request.CheckStatuses,
+		CheckResults:    request.CheckResults,
+		AssigneeIDs:     request.AssigneeIDs,
+		TestEnvDateFrom: request.TestEnvDateFrom,
+		TestEnvDateTo:   request.TestEnvDateTo,
+		CheckDateFrom:   request.CheckDateFrom,
+		CheckDateTo:     request.CheckDateTo,
+		Sort:            request.Sort,
+		Order:           strings.ToLower(request.Order),
+	}
+}
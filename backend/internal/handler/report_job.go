@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/essentialkaos/translit/v3"
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type ReportJobHandler struct {
+	reportJobService service.ReportJobService
+}
+
+func NewReportJobHandler(reportJobService service.ReportJobService) *ReportJobHandler {
+	return &ReportJobHandler{reportJobService: reportJobService}
+}
+
+func (h *ReportJobHandler) CreateFolderJob(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.CreateReportJobRequest
+	if r.ContentLength != 0 {
+		if !decodeJSON(w, r, &request) {
+			return
+		}
+	}
+
+	job, err := h.reportJobService.CreateFolderJob(r.Context(), &service.CreateFolderReportJobParams{
+		FolderID:   folderID,
+		Format:     service.ReportFormat(strings.ToLower(strings.TrimSpace(request.Format))),
+		TemplateID: strings.TrimSpace(request.TemplateID),
+		TaskFilter: mapReportFilterRequest(request.Filter),
+		Principal:  principal,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	encodeJSON(w, job)
+}
+
+func (h *ReportJobHandler) CreateSummaryJob(w http.ResponseWriter, r *http.Request) {
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.CreateSummaryReportJobRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	job, err := h.reportJobService.CreateSummaryJob(r.Context(), &service.SummaryReportParams{
+		FolderIDs:  request.FolderIDs,
+		From:       request.From,
+		To:         request.To,
+		TemplateID: strings.TrimSpace(request.TemplateID),
+		TaskFilter: mapReportFilterRequest(request.Filter),
+		Principal:  principal,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	encodeJSON(w, job)
+}
+
+func (h *ReportJobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "id")
+	if jobID == "" {
+		httpMessage(w, r, "Job id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	job, err := h.reportJobService.Get(r.Context(), jobID, principal)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, job)
+}
+
+func (h *ReportJobHandler) Download(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "id")
+	if jobID == "" {
+		httpMessage(w, r, "Job id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	report, err := h.reportJobService.Download(r.Context(), jobID, principal)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	defer report.Content.Close()
+
+	w.Header().Set("Content-Type", report.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", translit.ICAO(report.FileName)))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", report.Size))
+
+	if _, err := io.Copy(w, report.Content); err != nil {
+		log.Printf("REPORT_JOB_ERROR: couldn't send report of job %s: %v", jobID, err)
+	}
+}
+
+func (h *ReportJobHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation), errors.Is(err, service.ErrUnsupportedFormat):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, store.ErrReportJobNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, service.ErrReportNotReady):
+		httpError(w, r, err, http.StatusConflict)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
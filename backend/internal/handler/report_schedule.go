@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type ReportScheduleHandler struct {
+	scheduleService service.ReportScheduleService
+}
+
+func NewReportScheduleHandler(scheduleService service.ReportScheduleService) *ReportScheduleHandler {
+	return &ReportScheduleHandler{scheduleService: scheduleService}
+}
+
+func (h *ReportScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.CreateReportScheduleRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	schedule, err := h.scheduleService.Create(r.Context(), &service.CreateReportScheduleParams{
+		Name:       request.Name,
+		Kind:       request.Kind,
+		FolderIDs:  request.FolderIDs,
+		Format:     request.Format,
+		Cron:       request.Cron,
+		Timezone:   request.Timezone,
+		Recipients: request.Recipients,
+		TaskFilter: mapReportFilterRequest(request.Filter),
+		Principal:  principal,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encodeJSON(w, schedule)
+}
+
+func (h *ReportScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	schedules, err := h.scheduleService.List(r.Context(), principal)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, schedules)
+}
+
+func (h *ReportScheduleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	scheduleID := chi.URLParam(r, "id")
+	if scheduleID == "" {
+		httpMessage(w, r, "Schedule id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	schedule, err := h.scheduleService.Get(r.Context(), scheduleID, principal)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, schedule)
+}
+
+func (h *ReportScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	scheduleID := chi.URLParam(r, "id")
+	if scheduleID == "" {
+		httpMessage(w, r, "Schedule id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.UpdateReportScheduleRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	schedule, err := h.scheduleService.Update(r.Context(), &service.UpdateReportScheduleParams{
+		ScheduleID: scheduleID,
+		Name:       request.Name,
+		Format:     request.Format,
+		Cron:       request.Cron,
+		Timezone:   request.Timezone,
+		Recipients: request.Recipients,
+		TaskFilter: mapReportFilterRequest(request.Filter),
+		Enabled:    request.Enabled,
+		Principal:  principal,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, schedule)
+}
+
+func (h *ReportScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	scheduleID := chi.URLParam(r, "id")
+	if scheduleID == "" {
+		httpMessage(w, r, "Schedule id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.scheduleService.Delete(r.Context(), scheduleID, principal); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+}
+
+func (h *ReportScheduleHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	scheduleID := chi.URLParam(r, "id")
+	if scheduleID == "" {
+		httpMessage(w, r, "Schedule id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	runs, err := h.scheduleService.ListRuns(r.Context(), &service.ListScheduleRunsParams{
+		ScheduleID: scheduleID,
+		Page:       getQueryInt(r.URL.Query(), "page", 1),
+		PageSize:   getQueryInt(r.URL.Query(), "pageSize", 10),
+		Principal:  principal,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, runs)
+}
+
+func (h *ReportScheduleHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation), errors.Is(err, service.ErrUnsupportedFormat):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, service.ErrAccessDenied):
+		httpError(w, r, err, http.StatusForbidden)
+	case errors.Is(err, store.ErrScheduleNotFound), errors.Is(err, store.ErrFolderNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type ReportTemplateHandler struct {
+	templateService service.ReportTemplateService
+}
+
+func NewReportTemplateHandler(templateService service.ReportTemplateService) *ReportTemplateHandler {
+	return &ReportTemplateHandler{templateService: templateService}
+}
+
+func (h *ReportTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.List(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, templates)
+}
+
+func (h *ReportTemplateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request dto.CreateReportTemplateRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	template, err := h.templateService.Create(r.Context(), &service.CreateReportTemplateParams{
+		Name:       request.Name,
+		Columns:    request.Columns,
+		DateFormat: request.DateFormat,
+		IsDefault:  request.IsDefault,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encodeJSON(w, template)
+}
+
+func (h *ReportTemplateHandler) Update(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "id")
+	if templateID == "" {
+		httpMessage(w, r, "Template id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	var request dto.UpdateReportTemplateRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	template, err := h.templateService.Update(r.Context(), &service.UpdateReportTemplateParams{
+		TemplateID: templateID,
+		Name:       request.Name,
+		Columns:    request.Columns,
+		DateFormat: request.DateFormat,
+		IsDefault:  request.IsDefault,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, template)
+}
+
+func (h *ReportTemplateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "id")
+	if templateID == "" {
+		httpMessage(w, r, "Template id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.templateService.Delete(r.Context(), templateID); err != nil {
+		h.writeError(w, r, err)
+	}
+}
+
+func (h *ReportTemplateHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, store.ErrTemplateNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, store.ErrTemplateNameTaken):
+		httpError(w, r, err, http.StatusConflict)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
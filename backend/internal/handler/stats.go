@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type StatsHandler struct {
+	statsService service.StatsService
+}
+
+func NewStatsHandler(statsService service.StatsService) *StatsHandler {
+	return &StatsHandler{statsService: statsService}
+}
+
+func (s *StatsHandler) FolderStats(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	stats, err := s.statsService.FolderStats(r.Context(), folderID)
+	if err != nil {
+		if errors.Is(err, store.ErrFolderNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	encodeJSON(w, stats)
+}
+
+func (s *StatsHandler) Burndown(w http.ResponseWriter, r *http.Request) {
+	folderID := chi.URLParam(r, "id")
+	if folderID == "" {
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	from, err := getQueryDate(r.URL.Query(), "from")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	to, err := getQueryDate(r.URL.Query(), "to")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	burndown, err := s.statsService.Burndown(r.Context(), &service.BurndownParams{
+		FolderID: folderID,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			httpError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, store.ErrFolderNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	encodeJSON(w, burndown)
+}
+
+func (s *StatsHandler) TeamWorkload(w http.ResponseWriter, r *http.Request) {
+	from, err := getQueryDate(r.URL.Query(), "from")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	to, err := getQueryDate(r.URL.Query(), "to")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	workload, err := s.statsService.TeamWorkload(r.Context(), &service.TeamWorkloadParams{
+		FolderID: getQueryString(r.URL.Query(), "folderId", ""),
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			httpError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, store.ErrFolderNotFound) {
+			httpError(w, r, err, http.StatusNotFound)
+			return
+		}
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	encodeJSON(w, workload)
+}
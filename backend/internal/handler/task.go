@@ -2,7 +2,6 @@ package handler
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -15,18 +14,19 @@ import (
 )
 
 type TaskHandler struct {
-	taskService service.TaskService
+	taskService   service.TaskService
+	accessService service.AccessService
 }
 
-func NewTaskHandler(taskService service.TaskService) *TaskHandler {
-	return &TaskHandler{taskService: taskService}
+func NewTaskHandler(taskService service.TaskService, accessService service.AccessService) *TaskHandler {
+	return &TaskHandler{taskService: taskService, accessService: accessService}
 }
 
 func (t *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 	folderID := chi.URLParam(r, "id")
 	var newTaskRequest dto.CreateTaskRequest
 	if folderID == "" {
-		http.Error(w, "Folder id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
 		return
 	}
 
@@ -36,7 +36,7 @@ func (t *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	creatorId, ok := appmw.UserIdFromContext(r.Context())
 	if !ok {
-		http.Error(w, "User id not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "User id not found in context", http.StatusInternalServerError)
 		return
 	}
 
@@ -52,14 +52,14 @@ func (t *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) || errors.Is(err, store.ErrUserNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
 		if errors.Is(err, domain.ErrValidation) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, r, err, http.StatusBadRequest)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -69,7 +69,7 @@ func (t *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (t *TaskHandler) ListByFolder(w http.ResponseWriter, r *http.Request) {
 	folderID := chi.URLParam(r, "id")
 	if folderID == "" {
-		http.Error(w, "Folder id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Folder id is missing in URL", http.StatusBadRequest)
 		return
 	}
 	page := getQueryInt(r.URL.Query(), "page", 1)
@@ -87,10 +87,10 @@ func (t *TaskHandler) ListByFolder(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) {
-			http.Error(w, fmt.Sprintf("Folder with ID: %s not found", folderID), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -100,29 +100,35 @@ func (t *TaskHandler) ListByFolder(w http.ResponseWriter, r *http.Request) {
 func (t *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
 	if taskID == "" {
-		http.Error(w, "Task id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Task id is missing in URL", http.StatusBadRequest)
 		return
 	}
 
-	if err := t.taskService.DeleteByID(r.Context(), taskID); err != nil {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	if err := t.taskService.DeleteByID(r.Context(), taskID, userID); err != nil {
 		if errors.Is(err, store.ErrTaskNotFound) {
-			http.Error(w, fmt.Sprintf("Task with ID: %s not found", taskID), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 	}
 }
 
 func (t *TaskHandler) UpdateByAdmin(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
 	if taskID == "" {
-		http.Error(w, "Task id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Task id is missing in URL", http.StatusBadRequest)
 		return
 	}
 
 	userID, ok := appmw.UserIdFromContext(r.Context())
 	if !ok || userID == "" {
-		http.Error(w, "UserID not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
@@ -131,6 +137,18 @@ func (t *TaskHandler) UpdateByAdmin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if taskUpdate.FolderID != nil {
+		principal, _ := appmw.PrincipalFromContext(r.Context())
+		if err := t.accessService.AuthorizeFolder(r.Context(), principal, *taskUpdate.FolderID, domain.PermTaskCreate); err != nil {
+			if errors.Is(err, service.ErrAccessDenied) {
+				httpError(w, r, err, http.StatusForbidden)
+				return
+			}
+			httpError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
 	if err := t.taskService.UpdateByAdmin(r.Context(), &service.UpdateTaskParams{
 		SoftName:          taskUpdate.SoftName,
 		RequestID:         taskUpdate.RequestID,
@@ -146,29 +164,29 @@ func (t *TaskHandler) UpdateByAdmin(w http.ResponseWriter, r *http.Request) {
 		CurrentUserID:     userID,
 	}); err != nil {
 		if errors.Is(err, domain.ErrValidation) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, r, err, http.StatusBadRequest)
 			return
 		}
 		if errors.Is(err, store.ErrFolderNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
 		if errors.Is(err, store.ErrUserNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
 		if errors.Is(err, store.ErrTaskNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 	}
 }
 
 func (t *TaskHandler) UpdateByUser(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
 	if taskID == "" {
-		http.Error(w, "Task id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Task id is missing in URL", http.StatusBadRequest)
 		return
 	}
 
@@ -179,7 +197,7 @@ func (t *TaskHandler) UpdateByUser(w http.ResponseWriter, r *http.Request) {
 
 	userID, ok := appmw.UserIdFromContext(r.Context())
 	if !ok || userID == "" {
-		http.Error(w, "UserID not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
@@ -191,58 +209,50 @@ func (t *TaskHandler) UpdateByUser(w http.ResponseWriter, r *http.Request) {
 		CurrentUserID: userID,
 	}); err != nil {
 		if errors.Is(err, domain.ErrValidation) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, r, err, http.StatusBadRequest)
 			return
 		}
 		if errors.Is(err, service.ErrNotAssignee) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			httpError(w, r, err, http.StatusBadRequest)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 	}
 }
 
 func (t *TaskHandler) Details(w http.ResponseWriter, r *http.Request) {
 	taskID := chi.URLParam(r, "id")
 	if taskID == "" {
-		http.Error(w, "Task id is missing in URL", http.StatusBadRequest)
+		httpMessage(w, r, "Task id is missing in URL", http.StatusBadRequest)
 		return
 	}
 
 	view := getQueryString(r.URL.Query(), "view", "")
 
-	roles, ok := appmw.UserRolesFromContext(r.Context())
-	if !ok || len(roles) == 0 {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-	userID, ok := appmw.UserIdFromContext(r.Context())
-	if !ok || userID == "" {
-		http.Error(w, "UserID not found in context", http.StatusInternalServerError)
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
-	task, err := t.taskService.GetDetails(r.Context(), taskID, userID)
+	task, err := t.taskService.GetDetails(r.Context(), taskID, principal.UserID)
 	if err != nil {
 		if errors.Is(err, store.ErrTaskNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	isAdmin := isAdmin(roles)
-
-	if !isAdmin && task.AssigneeID != userID {
-		http.Error(w, fmt.Sprintf("user with ID: %s is not assignee task with ID: %s", userID, taskID), http.StatusForbidden)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
 	switch {
 	case strings.EqualFold(view, "full"):
-		if !isAdmin {
-			http.Error(w, "Forbidden: 'fill' view is not allowed for this user", http.StatusForbidden)
+		if err := t.accessService.AuthorizeFolder(r.Context(), principal, task.FolderID, domain.PermTaskUpdate); err != nil {
+			if errors.Is(err, service.ErrAccessDenied) {
+				httpMessage(w, r, "Forbidden: 'full' view is not allowed for this user", http.StatusForbidden)
+				return
+			}
+			httpError(w, r, err, http.StatusInternalServerError)
 			return
 		}
 
@@ -276,14 +286,14 @@ func (t *TaskHandler) Details(w http.ResponseWriter, r *http.Request) {
 
 		encodeJSON(w, response)
 	default:
-		http.Error(w, "unknown view", http.StatusNotFound)
+		httpMessage(w, r, "unknown view", http.StatusNotFound)
 	}
 }
 
 func (t *TaskHandler) ListUserTasks(w http.ResponseWriter, r *http.Request) {
 	userID, ok := appmw.UserIdFromContext(r.Context())
 	if !ok || userID == "" {
-		http.Error(w, "UserID not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
@@ -302,21 +312,12 @@ func (t *TaskHandler) ListUserTasks(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if errors.Is(err, store.ErrUserNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
 	encodeJSON(w, tasks)
 }
-
-func isAdmin(s []string) bool {
-	for _, role := range s {
-		if strings.EqualFold(role, "admin") {
-			return true
-		}
-	}
-	return false
-}
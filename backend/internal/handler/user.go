@@ -5,13 +5,16 @@ import (
 	"net/http"
 
 	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
 	"github.com/pesos228/bug-tracker/internal/service"
 	"github.com/pesos228/bug-tracker/internal/store"
 )
 
 type UserHandler struct {
-	userService service.UserService
+	userService         service.UserService
+	notificationService service.NotificationService
 }
 
 func (u *UserHandler) Search(w http.ResponseWriter, r *http.Request) {
@@ -26,7 +29,7 @@ func (u *UserHandler) Search(w http.ResponseWriter, r *http.Request) {
 	})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -36,57 +39,111 @@ func (u *UserHandler) Search(w http.ResponseWriter, r *http.Request) {
 func (u *UserHandler) AboutUser(w http.ResponseWriter, r *http.Request) {
 	userID, ok := appmw.UserIdFromContext(r.Context())
 	if !ok || userID == "" {
-		http.Error(w, "UserID not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
 	firstName, ok := appmw.UserFirstNameFromContext(r.Context())
 	if !ok {
-		http.Error(w, "FirstName not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "FirstName not found in context", http.StatusInternalServerError)
 		return
 	}
 
 	lastName, ok := appmw.UserLastNameFromContext(r.Context())
 	if !ok {
-		http.Error(w, "LastName not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "LastName not found in context", http.StatusInternalServerError)
 		return
 	}
 
-	roles, ok := appmw.UserRolesFromContext(r.Context())
-	if !ok || len(roles) == 0 {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	principal, ok := appmw.PrincipalFromContext(r.Context())
+	if !ok {
+		httpMessage(w, r, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	permissions := make([]string, len(principal.Permissions))
+	for i, permission := range principal.Permissions {
+		permissions[i] = string(permission)
+	}
+
+	unread, err := u.notificationService.UnreadCount(r.Context(), userID)
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	folders, err := u.userService.Memberships(r.Context(), userID)
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
 	response := dto.UserInfoResponse{
-		FirstName: firstName,
-		LastName:  lastName,
-		IsAdmin:   isAdmin(roles),
+		FirstName:   firstName,
+		LastName:    lastName,
+		IsAdmin:     principal.IsAdmin,
+		Permissions: permissions,
+		Folders:     folders,
+		Locale:      string(i18n.FromContext(r.Context())),
+
+		UnreadNotifications: unread,
 	}
 
 	encodeJSON(w, response)
 }
 
+func (u *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	profile, err := u.userService.UpdateProfile(r.Context(), &service.UpdateProfileParams{
+		UserID:      userID,
+		Locale:      req.Locale,
+		ChatHandles: req.ChatHandles,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrValidation):
+			httpError(w, r, err, http.StatusBadRequest)
+		case errors.Is(err, store.ErrUserNotFound):
+			httpError(w, r, err, http.StatusNotFound)
+		default:
+			httpError(w, r, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	encodeJSON(w, profile)
+}
+
 func (u *UserHandler) Stats(w http.ResponseWriter, r *http.Request) {
 	userID, ok := appmw.UserIdFromContext(r.Context())
 	if !ok || userID == "" {
-		http.Error(w, "UserID not found in context", http.StatusInternalServerError)
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
 		return
 	}
 
 	stats, err := u.userService.GetStats(r.Context(), userID)
 	if err != nil {
 		if errors.Is(err, store.ErrUserNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			httpError(w, r, err, http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
 	encodeJSON(w, stats)
 }
 
-func NewUserHandler(userService service.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService service.UserService, notificationService service.NotificationService) *UserHandler {
+	return &UserHandler{userService: userService, notificationService: notificationService}
 }
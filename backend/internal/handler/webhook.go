@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pesos228/bug-tracker/internal/appmw"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/service"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := appmw.UserIdFromContext(r.Context())
+	if !ok || userID == "" {
+		httpMessage(w, r, "UserID not found in context", http.StatusInternalServerError)
+		return
+	}
+
+	var request dto.CreateWebhookRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	hook, err := h.webhookService.Create(r.Context(), &service.CreateWebhookParams{
+		Name:      request.Name,
+		URL:       request.URL,
+		Secret:    request.Secret,
+		Events:    request.Events,
+		FolderID:  request.FolderID,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encodeJSON(w, hook)
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	hooks, err := h.webhookService.List(
+		r.Context(),
+		getQueryInt(r.URL.Query(), "page", 1),
+		getQueryInt(r.URL.Query(), "pageSize", 10),
+	)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, hooks)
+}
+
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "id")
+	if webhookID == "" {
+		httpMessage(w, r, "Webhook id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	hook, err := h.webhookService.Get(r.Context(), webhookID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, hook)
+}
+
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "id")
+	if webhookID == "" {
+		httpMessage(w, r, "Webhook id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	var request dto.UpdateWebhookRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	hook, err := h.webhookService.Update(r.Context(), &service.UpdateWebhookParams{
+		WebhookID: webhookID,
+		Name:      request.Name,
+		URL:       request.URL,
+		Secret:    request.Secret,
+		Events:    request.Events,
+		FolderID:  request.FolderID,
+		Enabled:   request.Enabled,
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, hook)
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "id")
+	if webhookID == "" {
+		httpMessage(w, r, "Webhook id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookService.Delete(r.Context(), webhookID); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "id")
+	if webhookID == "" {
+		httpMessage(w, r, "Webhook id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(
+		r.Context(),
+		webhookID,
+		getQueryInt(r.URL.Query(), "page", 1),
+		getQueryInt(r.URL.Query(), "pageSize", 10),
+	)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, deliveries)
+}
+
+func (h *WebhookHandler) SendTest(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "id")
+	if webhookID == "" {
+		httpMessage(w, r, "Webhook id is missing in URL", http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.webhookService.SendTest(r.Context(), webhookID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	encodeJSON(w, delivery)
+}
+
+func (h *WebhookHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		httpError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, store.ErrWebhookNotFound), errors.Is(err, store.ErrFolderNotFound):
+		httpError(w, r, err, http.StatusNotFound)
+	default:
+		httpError(w, r, err, http.StatusInternalServerError)
+	}
+}
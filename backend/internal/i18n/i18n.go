@@ -0,0 +1,160 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	Russian Locale = "ru"
+	English Locale = "en"
+
+	DefaultLocale = Russian
+)
+
+var Supported = []Locale{Russian, English}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+type catalog struct {
+	Messages map[string]string `json:"messages"`
+	Errors   map[string]string `json:"errors"`
+}
+
+var catalogs = mustLoadCatalogs()
+
+type localeKey struct{}
+
+func mustLoadCatalogs() map[Locale]*catalog {
+	result := make(map[Locale]*catalog, len(Supported))
+	for _, locale := range Supported {
+		data, err := localeFiles.ReadFile(fmt.Sprintf("locales/%s.json", locale))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for locale '%s': %v", locale, err))
+		}
+
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog for locale '%s': %v", locale, err))
+		}
+		result[locale] = &c
+	}
+	return result
+}
+
+func T(locale Locale, key string, args ...any) string {
+	message, ok := lookup(locale, key)
+	if !ok {
+		message, ok = lookup(DefaultLocale, key)
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+func lookup(locale Locale, key string) (string, bool) {
+	c, ok := catalogs[locale]
+	if !ok {
+		return "", false
+	}
+	message, ok := c.Messages[key]
+	return message, ok
+}
+
+// LocalizeError translates the text of every sentinel that err wraps, keeping the rest of the message.
+func LocalizeError(locale Locale, err error, sentinels ...error) string {
+	message := err.Error()
+	for _, sentinel := range sentinels {
+		if errors.Is(err, sentinel) {
+			message = strings.Replace(message, sentinel.Error(), TranslateError(locale, sentinel.Error()), 1)
+		}
+	}
+	return message
+}
+
+func TranslateError(locale Locale, message string) string {
+	c, ok := catalogs[locale]
+	if !ok || len(c.Errors) == 0 {
+		return message
+	}
+
+	if translation, ok := c.Errors[message]; ok {
+		return translation
+	}
+	return message
+}
+
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	for _, locale := range Supported {
+		if string(locale) == tag {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+func Negotiate(acceptLanguage string) (Locale, bool) {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{locale: locale, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale, true
+}
+
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
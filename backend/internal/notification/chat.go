@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+)
+
+const maxChatErrorBody = 512
+
+type chatPoster interface {
+	post(handle, text string) error
+}
+
+type chatNotifier struct {
+	channel   domain.ChatChannel
+	poster    chatPoster
+	publicURL string
+}
+
+func (c *chatNotifier) NotifyAboutNewTask(user *domain.User, task *domain.Task) error {
+	locale := userLocale(user)
+	return c.send(user,
+		i18n.T(locale, "email.new_task.subject", task.SoftName),
+		taskURL(c.publicURL, task.ID),
+	)
+}
+
+func (c *chatNotifier) NotifyAboutNewTasks(user *domain.User, folder *domain.Folder, tasks []*domain.Task) error {
+	locale := userLocale(user)
+	lines := []string{i18n.T(locale, "email.new_tasks.subject", folder.Name)}
+	for _, task := range tasks {
+		lines = append(lines, "• "+task.SoftName)
+	}
+	lines = append(lines, folderURL(c.publicURL, folder.ID))
+	return c.send(user, lines...)
+}
+
+func (c *chatNotifier) NotifyAboutResult(user *domain.User, task *domain.Task) error {
+	locale := userLocale(user)
+	result := "result.none"
+	if task.CheckResult != "" {
+		result = "result." + string(task.CheckResult)
+	}
+
+	lines := []string{
+		i18n.T(locale, "email.result.subject", task.SoftName),
+		i18n.T(locale, "email.result.status", i18n.T(locale, "status."+string(task.CheckStatus))),
+		i18n.T(locale, "email.result.result", i18n.T(locale, result)),
+	}
+	if task.Comment != "" {
+		lines = append(lines, i18n.T(locale, "email.result.comment", task.Comment))
+	}
+	lines = append(lines, taskURL(c.publicURL, task.ID))
+	return c.send(user, lines...)
+}
+
+func (c *chatNotifier) NotifyAboutReassignment(user *domain.User, task *domain.Task, assigned bool) error {
+	locale := userLocale(user)
+	subject := "email.reassigned.subject_removed"
+	if assigned {
+		subject = "email.reassigned.subject_assigned"
+	}
+	return c.send(user, i18n.T(locale, subject, task.SoftName), taskURL(c.publicURL, task.ID))
+}
+
+func (c *chatNotifier) NotifyAboutTaskUpdate(user *domain.User, task *domain.Task, changedFields []string) error {
+	locale := userLocale(user)
+	fields := make([]string, len(changedFields))
+	for i, field := range changedFields {
+		fields[i] = i18n.T(locale, "task.field."+field)
+	}
+	return c.send(user,
+		i18n.T(locale, "email.updated.subject", task.SoftName),
+		strings.Join(fields, ", "),
+		taskURL(c.publicURL, task.ID),
+	)
+}
+
+func (c *chatNotifier) NotifyAboutTaskDeletion(user *domain.User, task *DeletedTask) error {
+	return c.send(user, i18n.T(userLocale(user), "email.task_deleted.subject", task.SoftName))
+}
+
+func (c *chatNotifier) NotifyAboutFolderDeletion(user *domain.User, folderName string, taskCount int) error {
+	return c.send(user, i18n.T(userLocale(user), "email.folder_deleted.subject", folderName))
+}
+
+func (c *chatNotifier) NotifyAboutMention(user *domain.User, task *domain.Task, actorName string, source domain.MentionSource) error {
+	return c.send(user,
+		i18n.T(userLocale(user), "email.mention.subject", actorName, task.SoftName),
+		mentionedText(task, source),
+		taskURL(c.publicURL, task.ID),
+	)
+}
+
+func (c *chatNotifier) SendDigest(user *domain.User, entries []DigestEntry) error {
+	lines := []string{i18n.T(userLocale(user), "email.digest.subject", len(entries))}
+	for _, entry := range entries {
+		lines = append(lines, "• "+entry.Message)
+	}
+	return c.send(user, lines...)
+}
+
+// SendReport is a no-op: reports go to email recipients only.
+func (c *chatNotifier) SendReport(recipients []string, report *ReportEmail) error {
+	return nil
+}
+
+func (c *chatNotifier) send(user *domain.User, lines ...string) error {
+	handle := user.ChatHandle(c.channel)
+	if handle == "" {
+		return nil
+	}
+	if err := c.poster.post(handle, strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("couldn't post to %s: %w", c.channel, err)
+	}
+	return nil
+}
+
+func postJSON(client *http.Client, target string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("couldn't encode the message: %w", err)
+	}
+
+	response, err := client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// The URL may embed a token, so it must not end up in logs or dead letters.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("couldn't send the message: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxChatErrorBody))
+		return fmt.Errorf("unexpected response status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+type chatRequest struct {
+	path    string
+	payload map[string]any
+}
+
+func newChatServer(t *testing.T, status int) (*httptest.Server, *[]chatRequest) {
+	t.Helper()
+
+	var requests []chatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("couldn't decode the payload: %v", err)
+		}
+		requests = append(requests, chatRequest{path: r.URL.Path, payload: payload})
+
+		w.WriteHeader(status)
+		w.Write([]byte("channel_not_found"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func chatUser(channel domain.ChatChannel, handle string) *domain.User {
+	return &domain.User{
+		FirstName:   "Alex",
+		Locale:      "en",
+		ChatHandles: map[domain.ChatChannel]string{channel: handle},
+	}
+}
+
+func chatTask() *domain.Task {
+	return &domain.Task{BaseModel: domain.BaseModel{ID: "task-1"}, SoftName: "Payments Gateway"}
+}
+
+func TestChatNotifiersPostPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  domain.ChatChannel
+		handle   string
+		notifier func(url string) Notifier
+		path     string
+		want     map[string]string
+	}{
+		{
+			name:    "telegram",
+			channel: domain.ChatTelegram,
+			handle:  "123456789",
+			notifier: func(url string) Notifier {
+				return NewTelegramNotifier(url+"/", "secret", "https://tracker.test", http.DefaultClient)
+			},
+			path: "/botsecret/sendMessage",
+			want: map[string]string{"chat_id": "123456789"},
+		},
+		{
+			name:    "slack",
+			channel: domain.ChatSlack,
+			handle:  "U024BE7LH",
+			notifier: func(url string) Notifier {
+				return NewSlackNotifier(url+"/hook", "https://tracker.test", http.DefaultClient)
+			},
+			path: "/hook",
+			want: map[string]string{},
+		},
+		{
+			name:    "mattermost",
+			channel: domain.ChatMattermost,
+			handle:  "alex",
+			notifier: func(url string) Notifier {
+				return NewMattermostNotifier(url+"/hook", "https://tracker.test", http.DefaultClient)
+			},
+			path: "/hook",
+			want: map[string]string{"channel": "@alex"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newChatServer(t, http.StatusOK)
+
+			if err := tt.notifier(server.URL).NotifyAboutNewTask(chatUser(tt.channel, tt.handle), chatTask()); err != nil {
+				t.Fatalf("NotifyAboutNewTask() error = %v", err)
+			}
+			if len(*requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(*requests))
+			}
+
+			request := (*requests)[0]
+			if request.path != tt.path {
+				t.Errorf("path = %q, want %q", request.path, tt.path)
+			}
+			for key, value := range tt.want {
+				if request.payload[key] != value {
+					t.Errorf("payload[%q] = %v, want %q", key, request.payload[key], value)
+				}
+			}
+
+			text, _ := request.payload["text"].(string)
+			if !strings.Contains(text, "https://tracker.test/tasks/task-1") {
+				t.Errorf("text %q doesn't link the task", text)
+			}
+			if tt.channel == domain.ChatSlack && !strings.HasPrefix(text, "<@U024BE7LH> ") {
+				t.Errorf("text %q doesn't mention the member", text)
+			}
+		})
+	}
+}
+
+func TestChatNotifierReportsNonSuccessStatus(t *testing.T) {
+	server, _ := newChatServer(t, http.StatusBadRequest)
+	notifier := NewMattermostNotifier(server.URL, "https://tracker.test", http.DefaultClient)
+
+	err := notifier.NotifyAboutNewTask(chatUser(domain.ChatMattermost, "alex"), chatTask())
+	if err == nil {
+		t.Fatal("NotifyAboutNewTask() error = nil, want an error")
+	}
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("error %q doesn't carry the status and the response body", err)
+	}
+}
+
+func TestTelegramErrorHidesToken(t *testing.T) {
+	notifier := NewTelegramNotifier("http://127.0.0.1:1", "secret", "https://tracker.test", http.DefaultClient)
+
+	err := notifier.NotifyAboutNewTask(chatUser(domain.ChatTelegram, "123456789"), chatTask())
+	if err == nil {
+		t.Fatal("NotifyAboutNewTask() error = nil, want an error")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error %q leaks the bot token", err)
+	}
+}
+
+func TestChatNotifierSkipsUsersWithoutHandle(t *testing.T) {
+	server, requests := newChatServer(t, http.StatusOK)
+	notifier := NewSlackNotifier(server.URL, "https://tracker.test", http.DefaultClient)
+
+	if err := notifier.NotifyAboutNewTask(chatUser(domain.ChatTelegram, "123456789"), chatTask()); err != nil {
+		t.Fatalf("NotifyAboutNewTask() error = %v", err)
+	}
+	if len(*requests) != 0 {
+		t.Errorf("got %d requests, want none", len(*requests))
+	}
+}
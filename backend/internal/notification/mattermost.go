@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+type mattermostPoster struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func (m *mattermostPoster) post(handle, text string) error {
+	return postJSON(m.client, m.webhookURL, map[string]string{
+		"channel": "@" + handle,
+		"text":    text,
+	})
+}
+
+func NewMattermostNotifier(webhookURL, publicURL string, client *http.Client) Notifier {
+	return &chatNotifier{
+		channel:   domain.ChatMattermost,
+		poster:    &mattermostPoster{webhookURL: webhookURL, client: client},
+		publicURL: publicURL,
+	}
+}
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+type Channel struct {
+	Name     string
+	Notifier Notifier
+}
+
+type ChannelFilter interface {
+	Except(delivered []string) Notifier
+}
+
+// PartialDeliveryError lists the channels that got the notification before others failed.
+type PartialDeliveryError struct {
+	Delivered []string
+	Err       error
+}
+
+func (e *PartialDeliveryError) Error() string {
+	return fmt.Sprintf("delivered via %v: %v", e.Delivered, e.Err)
+}
+
+func (e *PartialDeliveryError) Unwrap() error {
+	return e.Err
+}
+
+type multiNotifier struct {
+	channels []Channel
+}
+
+func NewMultiNotifier(channels ...Channel) Notifier {
+	return &multiNotifier{channels: channels}
+}
+
+func (m *multiNotifier) Except(delivered []string) Notifier {
+	channels := make([]Channel, 0, len(m.channels))
+	for _, channel := range m.channels {
+		if !slices.Contains(delivered, channel.Name) {
+			channels = append(channels, channel)
+		}
+	}
+	return &multiNotifier{channels: channels}
+}
+
+func (m *multiNotifier) NotifyAboutNewTask(user *domain.User, task *domain.Task) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutNewTask(user, task) })
+}
+
+func (m *multiNotifier) NotifyAboutNewTasks(user *domain.User, folder *domain.Folder, tasks []*domain.Task) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutNewTasks(user, folder, tasks) })
+}
+
+func (m *multiNotifier) NotifyAboutResult(user *domain.User, task *domain.Task) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutResult(user, task) })
+}
+
+func (m *multiNotifier) NotifyAboutReassignment(user *domain.User, task *domain.Task, assigned bool) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutReassignment(user, task, assigned) })
+}
+
+func (m *multiNotifier) NotifyAboutTaskUpdate(user *domain.User, task *domain.Task, changedFields []string) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutTaskUpdate(user, task, changedFields) })
+}
+
+func (m *multiNotifier) NotifyAboutTaskDeletion(user *domain.User, task *DeletedTask) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutTaskDeletion(user, task) })
+}
+
+func (m *multiNotifier) NotifyAboutFolderDeletion(user *domain.User, folderName string, taskCount int) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutFolderDeletion(user, folderName, taskCount) })
+}
+
+func (m *multiNotifier) NotifyAboutMention(user *domain.User, task *domain.Task, actorName string, source domain.MentionSource) error {
+	return m.each(func(n Notifier) error { return n.NotifyAboutMention(user, task, actorName, source) })
+}
+
+func (m *multiNotifier) SendDigest(user *domain.User, entries []DigestEntry) error {
+	return m.each(func(n Notifier) error { return n.SendDigest(user, entries) })
+}
+
+func (m *multiNotifier) SendReport(recipients []string, report *ReportEmail) error {
+	return m.each(func(n Notifier) error { return n.SendReport(recipients, report) })
+}
+
+func (m *multiNotifier) each(send func(n Notifier) error) error {
+	var delivered []string
+	var errs []error
+	for _, channel := range m.channels {
+		if err := send(channel.Notifier); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = append(delivered, channel.Name)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &PartialDeliveryError{Delivered: delivered, Err: errors.Join(errs...)}
+}
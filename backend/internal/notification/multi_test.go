@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+type fakeNotifier struct {
+	Notifier
+	err   error
+	calls int
+}
+
+func (f *fakeNotifier) NotifyAboutNewTask(user *domain.User, task *domain.Task) error {
+	f.calls++
+	return f.err
+}
+
+func TestMultiNotifierReportsPartialDelivery(t *testing.T) {
+	failure := errors.New("slack is down")
+	email, slack, telegram := &fakeNotifier{}, &fakeNotifier{err: failure}, &fakeNotifier{}
+	notifier := NewMultiNotifier(
+		Channel{Name: "email", Notifier: email},
+		Channel{Name: "slack", Notifier: slack},
+		Channel{Name: "telegram", Notifier: telegram},
+	)
+
+	err := notifier.NotifyAboutNewTask(&domain.User{}, &domain.Task{})
+
+	var partial *PartialDeliveryError
+	if !errors.As(err, &partial) {
+		t.Fatalf("error = %v, want a PartialDeliveryError", err)
+	}
+	if !slices.Equal(partial.Delivered, []string{"email", "telegram"}) {
+		t.Errorf("Delivered = %v, want [email telegram]", partial.Delivered)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("error %v doesn't wrap the channel failure", err)
+	}
+	if email.calls != 1 || slack.calls != 1 || telegram.calls != 1 {
+		t.Errorf("calls = %d/%d/%d, want every channel called once", email.calls, slack.calls, telegram.calls)
+	}
+}
+
+func TestMultiNotifierSucceedsWhenEveryChannelDelivers(t *testing.T) {
+	notifier := NewMultiNotifier(Channel{Name: "email", Notifier: &fakeNotifier{}})
+
+	if err := notifier.NotifyAboutNewTask(&domain.User{}, &domain.Task{}); err != nil {
+		t.Errorf("error = %v, want nil", err)
+	}
+}
+
+func TestMultiNotifierExceptSkipsDeliveredChannels(t *testing.T) {
+	email, slack := &fakeNotifier{}, &fakeNotifier{}
+	notifier := NewMultiNotifier(
+		Channel{Name: "email", Notifier: email},
+		Channel{Name: "slack", Notifier: slack},
+	)
+
+	retry := notifier.(ChannelFilter).Except([]string{"email"})
+	if err := retry.NotifyAboutNewTask(&domain.User{}, &domain.Task{}); err != nil {
+		t.Fatalf("error = %v, want nil", err)
+	}
+	if email.calls != 0 {
+		t.Errorf("email called %d times, want 0", email.calls)
+	}
+	if slack.calls != 1 {
+		t.Errorf("slack called %d times, want 1", slack.calls)
+	}
+}
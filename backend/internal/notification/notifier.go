@@ -2,17 +2,47 @@ package notification
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
-	"log"
+	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
-	"github.com/pesos228/bug-tracker/internal/templates"
+	"github.com/pesos228/bug-tracker/internal/i18n"
 	"github.com/wneessen/go-mail"
 )
 
 type Notifier interface {
-	NotifyAboutNewTask(user *domain.User, task *domain.Task)
+	NotifyAboutNewTask(user *domain.User, task *domain.Task) error
+	NotifyAboutNewTasks(user *domain.User, folder *domain.Folder, tasks []*domain.Task) error
+	NotifyAboutResult(user *domain.User, task *domain.Task) error
+	NotifyAboutReassignment(user *domain.User, task *domain.Task, assigned bool) error
+	NotifyAboutTaskUpdate(user *domain.User, task *domain.Task, changedFields []string) error
+	NotifyAboutTaskDeletion(user *domain.User, task *DeletedTask) error
+	NotifyAboutFolderDeletion(user *domain.User, folderName string, taskCount int) error
+	NotifyAboutMention(user *domain.User, task *domain.Task, actorName string, source domain.MentionSource) error
+	SendDigest(user *domain.User, entries []DigestEntry) error
+	SendReport(recipients []string, report *ReportEmail) error
+}
+
+type DeletedTask struct {
+	SoftName   string
+	RequestID  string
+	FolderName string
+}
+
+type DigestEntry struct {
+	Message string
+	TaskID  string
+}
+
+type ReportEmail struct {
+	Locale       i18n.Locale
+	ScheduleName string
+	FileName     string
+	ContentType  string
+	Data         []byte
+	GeneratedAt  time.Time
 }
 
 type emailNotifier struct {
@@ -22,6 +52,7 @@ type emailNotifier struct {
 	Password  string
 	From      string
 	publicURL string
+	renderer  EmailRenderer
 }
 
 type newTaskEmailData struct {
@@ -30,37 +61,261 @@ type newTaskEmailData struct {
 	TaskURL   string
 }
 
-func (e *emailNotifier) NotifyAboutNewTask(user *domain.User, task *domain.Task) {
-	m := mail.NewMsg()
-	if err := m.From(e.From); err != nil {
-		log.Printf("EMAIL_NOTIFICATION_ERROR: couldn't identify sender: %v", err)
-		return
+type newTasksSummaryEmailData struct {
+	FirstName  string
+	FolderName string
+	FolderURL  string
+	Tasks      []newTaskSummaryItem
+}
+
+type newTaskSummaryItem struct {
+	SoftName  string
+	RequestID string
+	TaskURL   string
+}
+
+type resultEmailData struct {
+	FirstName string
+	SoftName  string
+	Status    string
+	Result    string
+	Comment   string
+	TaskURL   string
+}
+
+type reassignedEmailData struct {
+	FirstName string
+	SoftName  string
+	Assigned  bool
+	TaskURL   string
+}
+
+type taskUpdatedEmailData struct {
+	FirstName string
+	SoftName  string
+	Fields    []string
+	TaskURL   string
+}
+
+type taskDeletedEmailData struct {
+	FirstName string
+	DeletedTask
+}
+
+type folderDeletedEmailData struct {
+	FirstName  string
+	FolderName string
+	TaskCount  int
+}
+
+type mentionEmailData struct {
+	FirstName string
+	ActorName string
+	SoftName  string
+	Source    domain.MentionSource
+	Text      string
+	TaskURL   string
+}
+
+type digestEmailData struct {
+	FirstName string
+	Entries   []digestEmailItem
+	AppURL    string
+}
+
+type digestEmailItem struct {
+	Message string
+	TaskURL string
+}
+
+type scheduledReportEmailData struct {
+	ScheduleName string
+	FileName     string
+	GeneratedAt  string
+	AppURL       string
+}
+
+func (e *emailNotifier) NotifyAboutNewTask(user *domain.User, task *domain.Task) error {
+	data := newTaskEmailData{
+		FirstName: user.FirstName,
+		SoftName:  task.SoftName,
+		TaskURL:   e.taskURL(task.ID),
 	}
-	if err := m.To(user.Email); err != nil {
-		log.Printf("EMAIL_NOTIFICATION_ERROR: couldn't identify the recipient: %v", err)
-		return
+
+	locale := userLocale(user)
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.new_task.subject", task.SoftName), "new_task", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutNewTasks(user *domain.User, folder *domain.Folder, tasks []*domain.Task) error {
+	items := make([]newTaskSummaryItem, len(tasks))
+	for i, task := range tasks {
+		items[i] = newTaskSummaryItem{
+			SoftName:  task.SoftName,
+			RequestID: task.RequestID,
+			TaskURL:   e.taskURL(task.ID),
+		}
 	}
 
-	tmpl, err := template.ParseFS(templates.Files, "new_task_email.html")
-	if err != nil {
-		log.Printf("EMAIL_NOTIFICATION_ERROR: couldn't parse the template: %v", err)
-		return
+	data := newTasksSummaryEmailData{
+		FirstName:  user.FirstName,
+		FolderName: folder.Name,
+		FolderURL:  folderURL(e.publicURL, folder.ID),
+		Tasks:      items,
 	}
 
-	data := newTaskEmailData{
+	locale := userLocale(user)
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.new_tasks.subject", folder.Name), "new_tasks_summary", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutResult(user *domain.User, task *domain.Task) error {
+	locale := userLocale(user)
+	result := "result.none"
+	if task.CheckResult != "" {
+		result = "result." + string(task.CheckResult)
+	}
+
+	data := resultEmailData{
 		FirstName: user.FirstName,
 		SoftName:  task.SoftName,
-		TaskURL:   fmt.Sprintf("%s/tasks/%s", e.publicURL, task.ID),
+		Status:    i18n.T(locale, "status."+string(task.CheckStatus)),
+		Result:    i18n.T(locale, result),
+		Comment:   task.Comment,
+		TaskURL:   e.taskURL(task.ID),
 	}
 
-	var bodyBuffer bytes.Buffer
-	if err := tmpl.Execute(&bodyBuffer, data); err != nil {
-		log.Printf("EMAIL_NOTIFICATION_ERROR: couldn't execute the template: %v", err)
-		return
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.result.subject", task.SoftName), "result_submitted", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutReassignment(user *domain.User, task *domain.Task, assigned bool) error {
+	locale := userLocale(user)
+	data := reassignedEmailData{
+		FirstName: user.FirstName,
+		SoftName:  task.SoftName,
+		Assigned:  assigned,
+		TaskURL:   e.taskURL(task.ID),
 	}
 
-	m.Subject(fmt.Sprintf("Новая задача: %s", task.SoftName))
-	m.SetBodyString(mail.TypeTextHTML, bodyBuffer.String())
+	subject := "email.reassigned.subject_removed"
+	if assigned {
+		subject = "email.reassigned.subject_assigned"
+	}
+
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, subject, task.SoftName), "task_reassigned", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutTaskUpdate(user *domain.User, task *domain.Task, changedFields []string) error {
+	locale := userLocale(user)
+	fields := make([]string, len(changedFields))
+	for i, field := range changedFields {
+		fields[i] = i18n.T(locale, "task.field."+field)
+	}
+
+	data := taskUpdatedEmailData{
+		FirstName: user.FirstName,
+		SoftName:  task.SoftName,
+		Fields:    fields,
+		TaskURL:   e.taskURL(task.ID),
+	}
+
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.updated.subject", task.SoftName), "task_updated", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutTaskDeletion(user *domain.User, task *DeletedTask) error {
+	locale := userLocale(user)
+	data := taskDeletedEmailData{
+		FirstName:   user.FirstName,
+		DeletedTask: *task,
+	}
+
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.task_deleted.subject", task.SoftName), "task_deleted", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutFolderDeletion(user *domain.User, folderName string, taskCount int) error {
+	locale := userLocale(user)
+	data := folderDeletedEmailData{
+		FirstName:  user.FirstName,
+		FolderName: folderName,
+		TaskCount:  taskCount,
+	}
+
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.folder_deleted.subject", folderName), "folder_deleted", data, nil)
+}
+
+func (e *emailNotifier) NotifyAboutMention(user *domain.User, task *domain.Task, actorName string, source domain.MentionSource) error {
+	data := mentionEmailData{
+		FirstName: user.FirstName,
+		ActorName: actorName,
+		SoftName:  task.SoftName,
+		Source:    source,
+		Text:      mentionedText(task, source),
+		TaskURL:   e.taskURL(task.ID),
+	}
+
+	locale := userLocale(user)
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.mention.subject", actorName, task.SoftName), "task_mentioned", data, nil)
+}
+
+func (e *emailNotifier) SendDigest(user *domain.User, entries []DigestEntry) error {
+	items := make([]digestEmailItem, len(entries))
+	for i, entry := range entries {
+		items[i] = digestEmailItem{Message: entry.Message}
+		if entry.TaskID != "" {
+			items[i].TaskURL = e.taskURL(entry.TaskID)
+		}
+	}
+
+	data := digestEmailData{
+		FirstName: user.FirstName,
+		Entries:   items,
+		AppURL:    e.publicURL,
+	}
+
+	locale := userLocale(user)
+	return e.deliver([]string{user.Email}, locale, i18n.T(locale, "email.digest.subject", len(entries)), "digest", data, nil)
+}
+
+func (e *emailNotifier) SendReport(recipients []string, report *ReportEmail) error {
+	data := scheduledReportEmailData{
+		ScheduleName: report.ScheduleName,
+		FileName:     report.FileName,
+		GeneratedAt:  report.GeneratedAt.Format("02.01.2006 15:04"),
+		AppURL:       e.publicURL,
+	}
+
+	attach := func(m *mail.Msg) error {
+		return m.AttachReader(
+			report.FileName,
+			bytes.NewReader(report.Data),
+			mail.WithFileContentType(mail.ContentType(report.ContentType)),
+		)
+	}
+
+	return e.deliver(recipients, report.Locale, i18n.T(report.Locale, "email.report.subject", report.ScheduleName), "scheduled_report", data, attach)
+}
+
+func (e *emailNotifier) deliver(to []string, locale i18n.Locale, subject, templateName string, data any, attach func(m *mail.Msg) error) error {
+	m := mail.NewMsg()
+	if err := m.From(e.From); err != nil {
+		return fmt.Errorf("couldn't identify sender: %w", err)
+	}
+	if err := m.To(to...); err != nil {
+		return fmt.Errorf("couldn't identify the recipient: %w", err)
+	}
+
+	rendered, err := e.renderer.Render(context.Background(), templateName, locale, subject, data)
+	if err != nil {
+		return err
+	}
+
+	m.Subject(rendered.Subject)
+	m.SetBodyString(mail.TypeTextPlain, rendered.Text)
+	m.AddAlternativeString(mail.TypeTextHTML, rendered.HTML)
+
+	if attach != nil {
+		if err := attach(m); err != nil {
+			return fmt.Errorf("couldn't attach file: %w", err)
+		}
+	}
 
 	client, err := mail.NewClient(
 		e.Host,
@@ -70,17 +325,57 @@ func (e *emailNotifier) NotifyAboutNewTask(user *domain.User, task *domain.Task)
 		mail.WithPassword(e.Password),
 	)
 	if err != nil {
-		log.Printf("EMAIL_NOTIFICATION_ERROR: couldn't create SMTP client: %v", err)
-		return
+		return fmt.Errorf("couldn't create SMTP client: %w", err)
 	}
 
 	if err := client.DialAndSend(m); err != nil {
-		log.Printf("EMAIL_NOTIFICATION_ERROR: couldn't send email: %v", err)
-		return
+		return fmt.Errorf("couldn't send email: %w", err)
 	}
+
+	return nil
+}
+
+// translateFuncs escapes the arguments itself, so catalog messages may carry markup.
+func translateFuncs(locale i18n.Locale) template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string, args ...any) template.HTML {
+			for i, arg := range args {
+				if s, ok := arg.(string); ok {
+					args[i] = template.HTMLEscapeString(s)
+				}
+			}
+			return template.HTML(i18n.T(locale, key, args...))
+		},
+	}
+}
+
+func mentionedText(task *domain.Task, source domain.MentionSource) string {
+	if source == domain.MentionInComment {
+		return task.Comment
+	}
+	return task.Description
+}
+
+func userLocale(user *domain.User) i18n.Locale {
+	if locale, ok := i18n.Parse(user.EffectiveLocale()); ok {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+func (e *emailNotifier) taskURL(taskID string) string {
+	return taskURL(e.publicURL, taskID)
+}
+
+func taskURL(publicURL, taskID string) string {
+	return fmt.Sprintf("%s/tasks/%s", publicURL, taskID)
+}
+
+func folderURL(publicURL, folderID string) string {
+	return fmt.Sprintf("%s/folders/%s/tasks", publicURL, folderID)
 }
 
-func NewEmailNotifier(host string, port int, username, password, from, publicURL string) Notifier {
+func NewEmailNotifier(host string, port int, username, password, from, publicURL string, renderer EmailRenderer) Notifier {
 	return &emailNotifier{
 		Host:      host,
 		Port:      port,
@@ -88,5 +383,6 @@ func NewEmailNotifier(host string, port int, username, password, from, publicURL
 		Password:  password,
 		From:      from,
 		publicURL: publicURL,
+		renderer:  renderer,
 	}
 }
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var extraBlankLines = regexp.MustCompile(`\n{3,}`)
+
+type textWriter struct {
+	out      strings.Builder
+	newlines int
+	space    bool
+}
+
+func (t *textWriter) text(s string) {
+	if s == "" {
+		return
+	}
+	if strings.TrimLeft(s[:1], " \t\r\n") == "" {
+		t.space = true
+	}
+	words := strings.Fields(s)
+	for i, word := range words {
+		if i > 0 {
+			t.space = true
+		}
+		if t.space && t.newlines == 0 && t.out.Len() > 0 {
+			t.out.WriteByte(' ')
+		}
+		t.out.WriteString(word)
+		t.space, t.newlines = false, 0
+	}
+	if len(words) > 0 && strings.TrimRight(s[len(s)-1:], " \t\r\n") == "" {
+		t.space = true
+	}
+}
+
+func (t *textWriter) newline(count int) {
+	t.space = false
+	for ; t.newlines < count && t.out.Len() > 0; t.newlines++ {
+		t.out.WriteByte('\n')
+	}
+}
+
+// htmlToText builds the text/plain part from the rendered HTML, so overrides maintain one template.
+func htmlToText(source string) string {
+	var writer textWriter
+	tokenizer := html.NewTokenizer(strings.NewReader(source))
+
+	skipDepth := 0
+	var href string
+	var linkStart int
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.Data {
+			case "head", "style", "script", "title":
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+			case "br":
+				writer.newline(1)
+			case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "table", "tr":
+				writer.newline(2)
+			case "hr":
+				writer.newline(2)
+				writer.text("----------")
+				writer.newline(2)
+			case "li":
+				writer.newline(1)
+				writer.text("- ")
+			case "a":
+				href, linkStart = "", writer.out.Len()
+				for _, attr := range token.Attr {
+					if attr.Key == "href" {
+						href = attr.Val
+					}
+				}
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "head", "style", "script", "title":
+				skipDepth = max(skipDepth-1, 0)
+			case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "table", "tr":
+				writer.newline(2)
+			case "li":
+				writer.newline(1)
+			case "a":
+				caption := strings.TrimSpace(writer.out.String()[linkStart:])
+				if href != "" && caption != href {
+					writer.space = true
+					writer.text("(" + href + ")")
+				}
+				href = ""
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				writer.text(token.Data)
+			}
+		}
+	}
+
+	lines := strings.Split(writer.out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(extraBlankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,354 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"github.com/pesos228/bug-tracker/internal/templates"
+)
+
+const (
+	sampleTaskID = "00000000-0000-0000-0000-000000000001"
+	// overrideTTL bounds how long other instances keep serving an override after it changes.
+	overrideTTL = 30 * time.Second
+)
+
+type RenderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type EmailRenderer interface {
+	Render(ctx context.Context, name string, locale i18n.Locale, defaultSubject string, data any) (*RenderedEmail, error)
+	Preview(ctx context.Context, name string, locale i18n.Locale, override *domain.EmailTemplate) (*RenderedEmail, error)
+	Invalidate(name string, locale i18n.Locale)
+}
+
+type emailTemplateSpec struct {
+	file   string
+	sample func(locale i18n.Locale, publicURL string) (string, any)
+}
+
+type compiledEmailTemplate struct {
+	body    *template.Template
+	subject *texttemplate.Template
+}
+
+// cachedOverride remembers a missing override too, as nil, so default emails don't query the store.
+type cachedOverride struct {
+	compiled *compiledEmailTemplate
+	loadedAt time.Time
+}
+
+type emailRenderer struct {
+	overrides store.EmailTemplateStore
+	publicURL string
+	base      map[string]*compiledEmailTemplate
+
+	mu    sync.Mutex
+	cache map[string]*cachedOverride
+}
+
+var emailTemplates = map[string]emailTemplateSpec{
+	"new_task": {
+		file: "new_task_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := newTaskEmailData{FirstName: "Alex", SoftName: "Payments Gateway 2.4", TaskURL: taskURL(publicURL, sampleTaskID)}
+			return i18n.T(locale, "email.new_task.subject", data.SoftName), data
+		},
+	},
+	"new_tasks_summary": {
+		file: "new_tasks_summary_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := newTasksSummaryEmailData{
+				FirstName:  "Alex",
+				FolderName: "Release 2.4",
+				FolderURL:  folderURL(publicURL, sampleTaskID),
+				Tasks: []newTaskSummaryItem{
+					{SoftName: "Payments Gateway 2.4", RequestID: "REQ-1024", TaskURL: taskURL(publicURL, sampleTaskID)},
+					{SoftName: "Billing Portal 1.9", RequestID: "REQ-1025", TaskURL: taskURL(publicURL, sampleTaskID)},
+				},
+			}
+			return i18n.T(locale, "email.new_tasks.subject", data.FolderName), data
+		},
+	},
+	"result_submitted": {
+		file: "result_submitted_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := resultEmailData{
+				FirstName: "Alex",
+				SoftName:  "Payments Gateway 2.4",
+				Status:    i18n.T(locale, "status."+string(domain.Checked)),
+				Result:    i18n.T(locale, "result."+string(domain.Success)),
+				Comment:   "Checked on the staging environment",
+				TaskURL:   taskURL(publicURL, sampleTaskID),
+			}
+			return i18n.T(locale, "email.result.subject", data.SoftName), data
+		},
+	},
+	"task_reassigned": {
+		file: "task_reassigned_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := reassignedEmailData{FirstName: "Alex", SoftName: "Payments Gateway 2.4", Assigned: true, TaskURL: taskURL(publicURL, sampleTaskID)}
+			return i18n.T(locale, "email.reassigned.subject_assigned", data.SoftName), data
+		},
+	},
+	"task_updated": {
+		file: "task_updated_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := taskUpdatedEmailData{
+				FirstName: "Alex",
+				SoftName:  "Payments Gateway 2.4",
+				Fields:    []string{i18n.T(locale, "task.field.description"), i18n.T(locale, "task.field.testEnvDateUpdate")},
+				TaskURL:   taskURL(publicURL, sampleTaskID),
+			}
+			return i18n.T(locale, "email.updated.subject", data.SoftName), data
+		},
+	},
+	"task_deleted": {
+		file: "task_deleted_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := taskDeletedEmailData{
+				FirstName:   "Alex",
+				DeletedTask: DeletedTask{SoftName: "Payments Gateway 2.4", RequestID: "REQ-1024", FolderName: "Release 2.4"},
+			}
+			return i18n.T(locale, "email.task_deleted.subject", data.SoftName), data
+		},
+	},
+	"folder_deleted": {
+		file: "folder_deleted_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := folderDeletedEmailData{FirstName: "Alex", FolderName: "Release 2.4", TaskCount: 3}
+			return i18n.T(locale, "email.folder_deleted.subject", data.FolderName), data
+		},
+	},
+	"task_mentioned": {
+		file: "task_mentioned_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := mentionEmailData{
+				FirstName: "Alex",
+				ActorName: "Maria Ivanova",
+				SoftName:  "Payments Gateway 2.4",
+				Source:    domain.MentionInComment,
+				Text:      "@Alex.Smirnov could you check the refund flow?",
+				TaskURL:   taskURL(publicURL, sampleTaskID),
+			}
+			return i18n.T(locale, "email.mention.subject", data.ActorName, data.SoftName), data
+		},
+	},
+	"digest": {
+		file: "digest_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := digestEmailData{
+				FirstName: "Alex",
+				Entries: []digestEmailItem{
+					{Message: i18n.T(locale, "inbox.task_assigned", "Payments Gateway 2.4"), TaskURL: taskURL(publicURL, sampleTaskID)},
+					{Message: i18n.T(locale, "inbox.folder_deleted", "Release 2.3", 2)},
+				},
+				AppURL: publicURL,
+			}
+			return i18n.T(locale, "email.digest.subject", len(data.Entries)), data
+		},
+	},
+	"scheduled_report": {
+		file: "scheduled_report_email.html",
+		sample: func(locale i18n.Locale, publicURL string) (string, any) {
+			data := scheduledReportEmailData{
+				ScheduleName: "Weekly status",
+				FileName:     "weekly-status.xlsx",
+				GeneratedAt:  time.Now().Format("02.01.2006 15:04"),
+				AppURL:       publicURL,
+			}
+			return i18n.T(locale, "email.report.subject", data.ScheduleName), data
+		},
+	},
+}
+
+func EmailTemplateNames() []string {
+	names := make([]string, 0, len(emailTemplates))
+	for name := range emailTemplates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func DefaultEmailTemplate(name string) (string, bool) {
+	spec, ok := emailTemplates[name]
+	if !ok {
+		return "", false
+	}
+	source, err := templates.Files.ReadFile(spec.file)
+	if err != nil {
+		return "", false
+	}
+	return string(source), true
+}
+
+// Render prefers the override for the locale; a broken or unavailable override falls back to the embedded template.
+func (r *emailRenderer) Render(ctx context.Context, name string, locale i18n.Locale, defaultSubject string, data any) (*RenderedEmail, error) {
+	compiled, ok := r.base[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template '%s'", name)
+	}
+
+	if override := r.override(ctx, name, locale); override != nil {
+		compiled = override
+	}
+	return compiled.execute(locale, defaultSubject, data)
+}
+
+// Preview renders sample data; a given override is compiled as is, which validates it.
+func (r *emailRenderer) Preview(ctx context.Context, name string, locale i18n.Locale, override *domain.EmailTemplate) (*RenderedEmail, error) {
+	spec, ok := emailTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown email template '%s'", store.ErrEmailTemplateNotFound, name)
+	}
+	subject, data := spec.sample(locale, r.publicURL)
+
+	if override == nil {
+		return r.Render(ctx, name, locale, subject, data)
+	}
+
+	compiled, err := compileEmailTemplate(override)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+	rendered, err := compiled.execute(locale, subject, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+	return rendered, nil
+}
+
+func (r *emailRenderer) Invalidate(name string, locale i18n.Locale) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, overrideKey(name, locale))
+}
+
+func (r *emailRenderer) override(ctx context.Context, name string, locale i18n.Locale) *compiledEmailTemplate {
+	if r.overrides == nil {
+		return nil
+	}
+
+	key := overrideKey(name, locale)
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < overrideTTL {
+		return cached.compiled
+	}
+
+	compiled, err := r.loadOverride(ctx, name, locale)
+	if err != nil {
+		log.Printf("NOTIFICATION_ERROR: couldn't load the %s/%s template override, using the default: %v", name, locale, err)
+		return nil
+	}
+
+	r.mu.Lock()
+	r.cache[key] = &cachedOverride{compiled: compiled, loadedAt: time.Now()}
+	r.mu.Unlock()
+	return compiled
+}
+
+func (r *emailRenderer) loadOverride(ctx context.Context, name string, locale i18n.Locale) (*compiledEmailTemplate, error) {
+	override, err := r.overrides.Find(ctx, name, string(locale))
+	if errors.Is(err, store.ErrEmailTemplateNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return compileEmailTemplate(override)
+}
+
+func overrideKey(name string, locale i18n.Locale) string {
+	return name + "/" + string(locale)
+}
+
+func compileEmailTemplate(override *domain.EmailTemplate) (*compiledEmailTemplate, error) {
+	body, err := template.New(override.Name).Funcs(translateFuncs(i18n.DefaultLocale)).Parse(override.Body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body template: %w", err)
+	}
+
+	compiled := &compiledEmailTemplate{body: body}
+	if override.Subject != "" {
+		compiled.subject, err = texttemplate.New(override.Name).Funcs(translateTextFuncs(i18n.DefaultLocale)).Parse(override.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject template: %w", err)
+		}
+	}
+	return compiled, nil
+}
+
+// execute runs a clone, since the parsed templates are shared and the translation funcs depend on the locale.
+func (c *compiledEmailTemplate) execute(locale i18n.Locale, defaultSubject string, data any) (*RenderedEmail, error) {
+	body, err := c.body.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't clone the template: %w", err)
+	}
+
+	var bodyBuffer bytes.Buffer
+	if err := body.Funcs(translateFuncs(locale)).Execute(&bodyBuffer, data); err != nil {
+		return nil, fmt.Errorf("couldn't execute the template: %w", err)
+	}
+
+	subject := defaultSubject
+	if c.subject != nil {
+		subjectTemplate, err := c.subject.Clone()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't clone the subject template: %w", err)
+		}
+
+		var subjectBuffer bytes.Buffer
+		if err := subjectTemplate.Funcs(translateTextFuncs(locale)).Execute(&subjectBuffer, data); err != nil {
+			return nil, fmt.Errorf("couldn't execute the subject template: %w", err)
+		}
+		subject = strings.Join(strings.Fields(subjectBuffer.String()), " ")
+	}
+
+	return &RenderedEmail{
+		Subject: subject,
+		HTML:    bodyBuffer.String(),
+		Text:    htmlToText(bodyBuffer.String()),
+	}, nil
+}
+
+func translateTextFuncs(locale i18n.Locale) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"t": func(key string, args ...any) string {
+			return i18n.T(locale, key, args...)
+		},
+	}
+}
+
+func NewEmailRenderer(overrides store.EmailTemplateStore, publicURL string) EmailRenderer {
+	base := make(map[string]*compiledEmailTemplate, len(emailTemplates))
+	for name, spec := range emailTemplates {
+		base[name] = &compiledEmailTemplate{
+			body: template.Must(template.New(spec.file).Funcs(translateFuncs(i18n.DefaultLocale)).ParseFS(templates.Files, spec.file)),
+		}
+	}
+
+	return &emailRenderer{
+		overrides: overrides,
+		publicURL: publicURL,
+		base:      base,
+		cache:     make(map[string]*cachedOverride),
+	}
+}
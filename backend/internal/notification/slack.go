@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+type slackPoster struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// post mentions the member in the webhook's channel, since Slack incoming webhooks can't send direct messages.
+func (s *slackPoster) post(handle, text string) error {
+	return postJSON(s.client, s.webhookURL, map[string]string{
+		"text": fmt.Sprintf("<@%s> %s", handle, text),
+	})
+}
+
+func NewSlackNotifier(webhookURL, publicURL string, client *http.Client) Notifier {
+	return &chatNotifier{
+		channel:   domain.ChatSlack,
+		poster:    &slackPoster{webhookURL: webhookURL, client: client},
+		publicURL: publicURL,
+	}
+}
@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+type telegramPoster struct {
+	apiURL string
+	token  string
+	client *http.Client
+}
+
+func (t *telegramPoster) post(handle, text string) error {
+	return postJSON(t.client, fmt.Sprintf("%s/bot%s/sendMessage", t.apiURL, t.token), map[string]any{
+		"chat_id":                  handle,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	})
+}
+
+func NewTelegramNotifier(apiURL, token, publicURL string, client *http.Client) Notifier {
+	return &chatNotifier{
+		channel:   domain.ChatTelegram,
+		poster:    &telegramPoster{apiURL: strings.TrimSuffix(apiURL, "/"), token: token, client: client},
+		publicURL: publicURL,
+	}
+}
@@ -0,0 +1,305 @@
+package pdf
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+//go:embed fonts/DejaVuSansCondensed.ttf
+var regularFont []byte
+
+//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+var boldFont []byte
+
+const (
+	fontFamily = "DejaVu"
+	lineHeight = 4.5
+	fontSize   = 8
+)
+
+var resultColors = map[domain.CheckResult][3]int{
+	domain.Success: {76, 175, 80},
+	domain.Failure: {244, 67, 54},
+	domain.Warning: {255, 152, 0},
+}
+
+type reportGenerator struct {
+}
+
+func (r *reportGenerator) Format() service.ReportFormat {
+	return service.FormatPDF
+}
+
+func (r *reportGenerator) ContentType() string {
+	return "application/pdf"
+}
+
+func (r *reportGenerator) Generate(layout *service.ReportLayout, meta *service.ReportMeta, tasks []*service.TaskReportRow) (*bytes.Buffer, error) {
+	doc := fpdf.New("L", "mm", "A4", "")
+	doc.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	doc.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	doc.SetTitle(layout.T("report.title_with_folder", meta.FolderName), true)
+	doc.SetCreator("Bug Tracker", true)
+	doc.SetMargins(10, 10, 10)
+	doc.SetAutoPageBreak(false, 10)
+	doc.SetFooterFunc(func() {
+		doc.SetY(-8)
+		doc.SetFont(fontFamily, "", 7)
+		doc.SetTextColor(128, 128, 128)
+		doc.CellFormat(0, 4, layout.T("report.page_footer", meta.FolderName, doc.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	r.writeTitlePage(doc, layout, meta, tasks)
+	r.writeTable(doc, layout, tasks)
+
+	if err := doc.Error(); err != nil {
+		return nil, fmt.Errorf("failed to build pdf: %w", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := doc.Output(buffer); err != nil {
+		return nil, fmt.Errorf("failed to write in buffer: %w", err)
+	}
+
+	return buffer, nil
+}
+
+func (r *reportGenerator) writeTitlePage(doc *fpdf.Fpdf, layout *service.ReportLayout, meta *service.ReportMeta, tasks []*service.TaskReportRow) {
+	doc.AddPage()
+	doc.SetTextColor(0, 0, 0)
+
+	doc.SetFont(fontFamily, "B", 20)
+	doc.CellFormat(0, 12, layout.T("report.title"), "", 1, "L", false, 0, "")
+	doc.SetFont(fontFamily, "B", 14)
+	pageWidth, _ := doc.GetPageSize()
+	left, _, right, _ := doc.GetMargins()
+	nameHeight := float64(len(doc.SplitText(meta.FolderName, pageWidth-left-right))) * 8
+	if doc.GetY()+nameHeight > r.pageBottom(doc) {
+		doc.AddPage()
+	}
+	doc.MultiCell(0, 8, meta.FolderName, "", "L", false)
+	doc.Ln(4)
+
+	doc.SetFont(fontFamily, "", 10)
+	info := [][2]string{
+		{layout.T("report.release_version"), valueOrDash(meta.ReleaseVersion)},
+		{layout.T("report.creator"), valueOrDash(meta.CreatorPerson)},
+		{layout.T("report.created_at"), layout.FormatDate(meta.CreatedAt)},
+		{layout.T("report.planned_start"), formatOptionalDate(layout, meta.PlannedStartDate)},
+		{layout.T("report.planned_end"), formatOptionalDate(layout, meta.PlannedEndDate)},
+		{layout.T("report.generated_at"), meta.GeneratedAt.Format("02.01.2006 15:04")},
+		{layout.T("report.total_tasks"), fmt.Sprintf("%d", len(tasks))},
+	}
+	for _, line := range info {
+		doc.SetFont(fontFamily, "B", 10)
+		doc.CellFormat(50, 6, line[0], "", 0, "L", false, 0, "")
+		doc.SetFont(fontFamily, "", 10)
+		doc.CellFormat(0, 6, line[1], "", 1, "L", false, 0, "")
+	}
+	doc.Ln(6)
+
+	statusCounts := make(map[domain.CheckStatus]int)
+	resultCounts := make(map[domain.CheckResult]int)
+	for _, task := range tasks {
+		statusCounts[task.CheckStatus]++
+		resultCounts[task.CheckResult]++
+	}
+
+	statuses := []domain.CheckStatus{domain.NotChecked, domain.Checked, domain.PartiallyChecked, domain.Failed}
+	statusRows := make([][2]string, len(statuses))
+	for i, status := range statuses {
+		statusRows[i] = [2]string{layout.Status(status), fmt.Sprintf("%d", statusCounts[status])}
+	}
+
+	results := []domain.CheckResult{domain.Success, domain.Failure, domain.Warning, ""}
+	resultRows := make([][2]string, len(results))
+	for i, result := range results {
+		resultRows[i] = [2]string{layout.Result(result), fmt.Sprintf("%d", resultCounts[result])}
+	}
+
+	r.writeSummaryTable(doc, layout.T("report.check_status"), layout.T("report.count"), statusRows)
+	doc.Ln(4)
+	r.writeSummaryTable(doc, layout.T("report.check_result"), layout.T("report.count"), resultRows)
+}
+
+func (r *reportGenerator) writeSummaryTable(doc *fpdf.Fpdf, title, countTitle string, rows [][2]string) {
+	doc.SetFont(fontFamily, "B", 10)
+	doc.SetFillColor(211, 211, 211)
+	doc.CellFormat(70, 7, title, "1", 0, "L", true, 0, "")
+	doc.CellFormat(25, 7, countTitle, "1", 1, "C", true, 0, "")
+
+	doc.SetFont(fontFamily, "", 10)
+	for _, row := range rows {
+		doc.CellFormat(70, 6, row[0], "1", 0, "L", false, 0, "")
+		doc.CellFormat(25, 6, row[1], "1", 1, "C", false, 0, "")
+	}
+}
+
+func (r *reportGenerator) writeTable(doc *fpdf.Fpdf, layout *service.ReportLayout, tasks []*service.TaskReportRow) {
+	widths := r.columnWidths(doc, layout.Widths())
+	headers := layout.Headers()
+	resultColumn := layout.ColumnIndex(domain.FieldCheckResult)
+
+	newPage := func() {
+		doc.AddPage()
+		r.writeHeader(doc, headers, widths)
+		doc.SetFont(fontFamily, "", fontSize)
+	}
+
+	newPage()
+	for _, task := range tasks {
+		lines := r.splitLines(doc, layout.Texts(task), widths)
+		total := maxLines(lines)
+
+		// A row that fits on a page is moved to the next one whole; a taller row is split across pages.
+		if doc.GetY()+linesHeight(total) > r.pageBottom(doc) {
+			newPage()
+		}
+		for start := 0; start < total; {
+			fit := int((r.pageBottom(doc) - doc.GetY() - 2) / lineHeight)
+			if fit < 1 {
+				newPage()
+				continue
+			}
+			end := min(start+fit, total)
+			r.writeRow(doc, sliceLines(lines, start, end), widths, linesHeight(end-start), resultColumn, task.CheckResult)
+			start = end
+		}
+	}
+}
+
+func (r *reportGenerator) pageBottom(doc *fpdf.Fpdf) float64 {
+	_, pageHeight := doc.GetPageSize()
+	_, _, _, bottomMargin := doc.GetMargins()
+	return pageHeight - bottomMargin
+}
+
+func (r *reportGenerator) writeHeader(doc *fpdf.Fpdf, headers []string, widths []float64) {
+	doc.SetFont(fontFamily, "B", fontSize)
+	doc.SetFillColor(211, 211, 211)
+	doc.SetTextColor(0, 0, 0)
+
+	lines := r.splitLines(doc, headers, widths)
+	r.writeCells(doc, lines, widths, linesHeight(maxLines(lines)), true)
+}
+
+func (r *reportGenerator) writeRow(doc *fpdf.Fpdf, values [][]string, widths []float64, height float64, resultColumn int, result domain.CheckResult) {
+	color, ok := resultColors[result]
+	if !ok {
+		color = [3]int{224, 224, 224}
+	}
+
+	x, y := doc.GetXY()
+	for i, value := range values {
+		fill := false
+		doc.SetTextColor(0, 0, 0)
+		if i == resultColumn {
+			fill = true
+			doc.SetFillColor(color[0], color[1], color[2])
+			if result == domain.Success || result == domain.Failure {
+				doc.SetTextColor(255, 255, 255)
+			}
+		}
+		r.writeCell(doc, x, y, widths[i], height, value, fill)
+		x += widths[i]
+	}
+	doc.SetTextColor(0, 0, 0)
+	doc.SetXY(x-sum(widths), y+height)
+}
+
+func (r *reportGenerator) writeCells(doc *fpdf.Fpdf, values [][]string, widths []float64, height float64, fill bool) {
+	x, y := doc.GetXY()
+	for i, value := range values {
+		r.writeCell(doc, x, y, widths[i], height, value, fill)
+		x += widths[i]
+	}
+	doc.SetXY(x-sum(widths), y+height)
+}
+
+func (r *reportGenerator) writeCell(doc *fpdf.Fpdf, x, y, width, height float64, lines []string, fill bool) {
+	style := "D"
+	if fill {
+		style = "FD"
+	}
+	doc.Rect(x, y, width, height, style)
+
+	offset := (height - float64(len(lines))*lineHeight) / 2
+	for i, line := range lines {
+		doc.SetXY(x, y+offset+float64(i)*lineHeight)
+		doc.CellFormat(width, lineHeight, line, "", 0, "C", false, 0, "")
+	}
+}
+
+func (r *reportGenerator) splitLines(doc *fpdf.Fpdf, values []string, widths []float64) [][]string {
+	lines := make([][]string, len(values))
+	for i, value := range values {
+		lines[i] = doc.SplitText(value, widths[i])
+	}
+	return lines
+}
+
+func maxLines(lines [][]string) int {
+	count := 1
+	for _, cell := range lines {
+		count = max(count, len(cell))
+	}
+	return count
+}
+
+func sliceLines(lines [][]string, start, end int) [][]string {
+	sliced := make([][]string, len(lines))
+	for i, cell := range lines {
+		sliced[i] = cell[min(start, len(cell)):min(end, len(cell))]
+	}
+	return sliced
+}
+
+func linesHeight(count int) float64 {
+	return float64(count)*lineHeight + 2
+}
+
+func (r *reportGenerator) columnWidths(doc *fpdf.Fpdf, columnWeights []float64) []float64 {
+	pageWidth, _ := doc.GetPageSize()
+	left, _, right, _ := doc.GetMargins()
+	available := pageWidth - left - right
+
+	total := sum(columnWeights)
+	widths := make([]float64, len(columnWeights))
+	for i, weight := range columnWeights {
+		widths[i] = available * weight / total
+	}
+	return widths
+}
+
+func sum(values []float64) float64 {
+	var total float64
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func formatOptionalDate(layout *service.ReportLayout, t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return layout.FormatDate(*t)
+}
+
+func valueOrDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+func NewReportGenerator() service.ReportGenerator {
+	return &reportGenerator{}
+}
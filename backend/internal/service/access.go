@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type AccessService interface {
+	AuthorizeFolder(ctx context.Context, principal *Principal, folderID string, permission domain.Permission) error
+	AuthorizeTask(ctx context.Context, principal *Principal, taskID string, permission domain.Permission) error
+	AuthorizeAnyFolder(ctx context.Context, principal *Principal, permission domain.Permission) error
+}
+
+var ErrAccessDenied = errors.New("access denied")
+
+type accessServiceImpl struct {
+	memberStore  store.FolderMemberStore
+	taskStore    store.TaskStore
+	mentionStore store.MentionStore
+}
+
+func (a *accessServiceImpl) AuthorizeFolder(ctx context.Context, principal *Principal, folderID string, permission domain.Permission) error {
+	if principal.Has(permission) {
+		return nil
+	}
+
+	member, err := a.memberStore.Find(ctx, folderID, principal.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrMemberNotFound) {
+			return fmt.Errorf("%w: user is not a member of folder with ID: %s", ErrAccessDenied, folderID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if !member.Role.Grants(permission) {
+		return fmt.Errorf("%w: permission '%s' in folder with ID: %s is required", ErrAccessDenied, permission, folderID)
+	}
+
+	return nil
+}
+
+func (a *accessServiceImpl) AuthorizeTask(ctx context.Context, principal *Principal, taskID string, permission domain.Permission) error {
+	task, err := a.taskStore.FindById(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, store.ErrTaskNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, taskID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if permission == domain.PermTaskRead {
+		if task.AssigneeID == principal.UserID {
+			return nil
+		}
+
+		mentioned, err := a.mentionStore.IsMentioned(ctx, taskID, principal.UserID)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+		if mentioned {
+			return nil
+		}
+	}
+
+	return a.AuthorizeFolder(ctx, principal, task.FolderID, permission)
+}
+
+func (a *accessServiceImpl) AuthorizeAnyFolder(ctx context.Context, principal *Principal, permission domain.Permission) error {
+	if principal.Has(permission) {
+		return nil
+	}
+
+	ok, err := a.memberStore.HasAnyRole(ctx, principal.UserID, domain.FolderRolesGranting(permission))
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: permission '%s' in any folder is required", ErrAccessDenied, permission)
+	}
+
+	return nil
+}
+
+func NewAccessService(memberStore store.FolderMemberStore, taskStore store.TaskStore, mentionStore store.MentionStore) AccessService {
+	return &accessServiceImpl{memberStore: memberStore, taskStore: taskStore, mentionStore: mentionStore}
+}
@@ -94,12 +94,12 @@ func (a *authServiceImpl) HandleCallback(ctx context.Context, code string, state
 		return "", fmt.Errorf("failed to parse token claims: %w", err)
 	}
 
-	newUser, err := domain.NewUser(verifiedToken.Subject, claims.Email, claims.GivenName, claims.FamilyName)
+	newUser, err := domain.NewUser(verifiedToken.Subject, claims.Email, claims.GivenName, claims.FamilyName, claims.Locale, claims.RealmAccess.Roles)
 	if err != nil {
 		return "", err
 	}
 
-	if err := a.UserStore.Save(ctx, newUser); err != nil {
+	if err := a.UserStore.SyncIdentity(ctx, newUser); err != nil {
 		return "", fmt.Errorf("failed to save new user: %w", err)
 	}
 
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/notification"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type UpdateEmailTemplateParams struct {
+	Name    string
+	Locale  string
+	Subject string
+	Body    string
+	UserID  string
+}
+
+type PreviewEmailTemplateParams struct {
+	Name    string
+	Locale  string
+	Subject *string
+	Body    *string
+	UserID  string
+}
+
+type EmailTemplateService interface {
+	List(ctx context.Context) ([]*dto.EmailTemplateSummary, error)
+	Get(ctx context.Context, name, locale string) (*dto.EmailTemplateResponse, error)
+	Update(ctx context.Context, params *UpdateEmailTemplateParams) (*dto.EmailTemplateResponse, error)
+	Reset(ctx context.Context, name, locale string) error
+	Preview(ctx context.Context, params *PreviewEmailTemplateParams) (*dto.EmailPreviewResponse, error)
+}
+
+type emailTemplateServiceImpl struct {
+	templateStore store.EmailTemplateStore
+	renderer      notification.EmailRenderer
+}
+
+func (e *emailTemplateServiceImpl) List(ctx context.Context) ([]*dto.EmailTemplateSummary, error) {
+	overrides, err := e.templateStore.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	var response []*dto.EmailTemplateSummary
+	for _, name := range notification.EmailTemplateNames() {
+		for _, locale := range i18n.Supported {
+			summary := &dto.EmailTemplateSummary{Name: name, Locale: string(locale)}
+			for _, override := range overrides {
+				if override.Name == name && override.Locale == string(locale) {
+					summary.Overridden = true
+					summary.UpdatedAt = &override.UpdatedAt
+				}
+			}
+			response = append(response, summary)
+		}
+	}
+
+	return response, nil
+}
+
+func (e *emailTemplateServiceImpl) Get(ctx context.Context, name, locale string) (*dto.EmailTemplateResponse, error) {
+	if err := checkEmailTemplate(name, locale); err != nil {
+		return nil, err
+	}
+
+	override, err := e.templateStore.Find(ctx, name, locale)
+	if err == nil {
+		return mapEmailTemplateToResponse(override), nil
+	}
+	if !errors.Is(err, store.ErrEmailTemplateNotFound) {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	body, _ := notification.DefaultEmailTemplate(name)
+	return &dto.EmailTemplateResponse{
+		Name:   name,
+		Locale: locale,
+		Body:   body,
+	}, nil
+}
+
+// Update stores the override only if it compiles and renders with the sample data of the template.
+func (e *emailTemplateServiceImpl) Update(ctx context.Context, params *UpdateEmailTemplateParams) (*dto.EmailTemplateResponse, error) {
+	if err := checkEmailTemplate(params.Name, params.Locale); err != nil {
+		return nil, err
+	}
+
+	template, err := e.templateStore.Find(ctx, params.Name, params.Locale)
+	switch {
+	case err == nil:
+		err = template.Update(params.Subject, params.Body, params.UserID)
+	case errors.Is(err, store.ErrEmailTemplateNotFound):
+		template, err = domain.NewEmailTemplate(&domain.EmailTemplateParams{
+			Name:      params.Name,
+			Locale:    params.Locale,
+			Subject:   params.Subject,
+			Body:      params.Body,
+			UpdatedBy: params.UserID,
+		})
+	default:
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := e.renderer.Preview(ctx, params.Name, i18n.Locale(params.Locale), template); err != nil {
+		return nil, err
+	}
+
+	if err := e.templateStore.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+	e.renderer.Invalidate(params.Name, i18n.Locale(params.Locale))
+
+	return mapEmailTemplateToResponse(template), nil
+}
+
+func (e *emailTemplateServiceImpl) Reset(ctx context.Context, name, locale string) error {
+	if err := checkEmailTemplate(name, locale); err != nil {
+		return err
+	}
+
+	if err := e.templateStore.Delete(ctx, name, locale); err != nil {
+		if errors.Is(err, store.ErrEmailTemplateNotFound) {
+			return fmt.Errorf("%w: no override for %s/%s", err, name, locale)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+	e.renderer.Invalidate(name, i18n.Locale(locale))
+	return nil
+}
+
+func (e *emailTemplateServiceImpl) Preview(ctx context.Context, params *PreviewEmailTemplateParams) (*dto.EmailPreviewResponse, error) {
+	current, err := e.Get(ctx, params.Name, params.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var draft *domain.EmailTemplate
+	if params.Subject != nil || params.Body != nil {
+		subject, body := current.Subject, current.Body
+		if params.Subject != nil {
+			subject = *params.Subject
+		}
+		if params.Body != nil {
+			body = *params.Body
+		}
+
+		draft, err = domain.NewEmailTemplate(&domain.EmailTemplateParams{
+			Name:      params.Name,
+			Locale:    params.Locale,
+			Subject:   subject,
+			Body:      body,
+			UpdatedBy: params.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rendered, err := e.renderer.Preview(ctx, params.Name, i18n.Locale(params.Locale), draft)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.EmailPreviewResponse{
+		Subject: rendered.Subject,
+		HTML:    rendered.HTML,
+		Text:    rendered.Text,
+	}, nil
+}
+
+func checkEmailTemplate(name, locale string) error {
+	if !slices.Contains(notification.EmailTemplateNames(), name) {
+		return fmt.Errorf("%w: unknown email template '%s'", store.ErrEmailTemplateNotFound, name)
+	}
+	if !slices.Contains(i18n.Supported, i18n.Locale(locale)) {
+		return fmt.Errorf("%w: unsupported locale '%s'", domain.ErrValidation, locale)
+	}
+	return nil
+}
+
+func mapEmailTemplateToResponse(template *domain.EmailTemplate) *dto.EmailTemplateResponse {
+	return &dto.EmailTemplateResponse{
+		Name:       template.Name,
+		Locale:     template.Locale,
+		Subject:    template.Subject,
+		Body:       template.Body,
+		Overridden: true,
+		UpdatedBy:  &template.UpdatedBy,
+		UpdatedAt:  &template.UpdatedAt,
+	}
+}
+
+func NewEmailTemplateService(templateStore store.EmailTemplateStore, renderer notification.EmailRenderer) EmailTemplateService {
+	return &emailTemplateServiceImpl{
+		templateStore: templateStore,
+		renderer:      renderer,
+	}
+}
@@ -4,25 +4,357 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
 	"github.com/pesos228/bug-tracker/internal/store"
 )
 
+type SearchFoldersParams struct {
+	Page      int
+	PageSize  int
+	Query     string
+	Principal *Principal
+}
+
+type FolderMemberParams struct {
+	FolderID string
+	UserID   string
+	Role     string
+}
+
+type UpdateFolderParams struct {
+	FolderID         string
+	Name             *string
+	Description      *string
+	ReleaseVersion   *string
+	PlannedStartDate *domain.DateChange
+	PlannedEndDate   *domain.DateChange
+	OwnerID          *string
+	ReportTemplateID *string
+}
+
+type CloneFolderParams struct {
+	SourceFolderID       string
+	Name                 string
+	Description          *string
+	ReleaseVersion       *string
+	CheckStatuses        []string
+	CheckResults         []string
+	AssigneeIDs          []string
+	TestEnvDateShiftDays int
+	CurrentUserID        string
+}
+
 type FolderService interface {
 	Save(ctx context.Context, name, userId string) (*dto.FolderCreatedResponse, error)
-	Search(ctx context.Context, page, pageSize int, query string) (*dto.FolderSearchResponse, error)
-	Delete(ctx context.Context, folderID string) error
+	Update(ctx context.Context, params *UpdateFolderParams) error
+	Clone(ctx context.Context, params *CloneFolderParams) (*dto.FolderCreatedResponse, error)
+	Search(ctx context.Context, params *SearchFoldersParams) (*dto.FolderSearchResponse, error)
+	Delete(ctx context.Context, folderID, currentUserID string) error
 	Details(ctx context.Context, folderId string) (*dto.FolderDetailsResponse, error)
+	ListMembers(ctx context.Context, folderID string) ([]*dto.FolderMemberResponse, error)
+	AddMember(ctx context.Context, params *FolderMemberParams) error
+	UpdateMember(ctx context.Context, params *FolderMemberParams) error
+	RemoveMember(ctx context.Context, folderID, userID string) error
+}
+
+var ErrLastFolderOwner = errors.New("folder must have at least one owner")
+
+type FolderServiceDeps struct {
+	FolderStore         store.FolderStore
+	MemberStore         store.FolderMemberStore
+	UserStore           store.UserStore
+	TaskStore           store.TaskStore
+	TemplateStore       store.ReportTemplateStore
+	TxManager           store.TxManager
+	NotificationService NotificationService
+	WebhookService      WebhookService
 }
 
 type folerServiceImpl struct {
-	folderStore store.FolderStore
+	folderStore         store.FolderStore
+	memberStore         store.FolderMemberStore
+	userStore           store.UserStore
+	taskStore           store.TaskStore
+	templateStore       store.ReportTemplateStore
+	txManager           store.TxManager
+	notificationService NotificationService
+	webhookService      WebhookService
+}
+
+func (f *folerServiceImpl) Clone(ctx context.Context, params *CloneFolderParams) (*dto.FolderCreatedResponse, error) {
+	source, err := f.folderStore.FindByID(ctx, params.SourceFolderID)
+	if err != nil {
+		if errors.Is(err, store.ErrFolderNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, params.SourceFolderID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		name = i18n.T(i18n.FromContext(ctx), "folder.copy_name", source.Name)
+	}
+
+	newFolder, err := domain.NewFolder(name, params.CurrentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	description := source.Description
+	if params.Description != nil {
+		description = *params.Description
+	}
+	if err := newFolder.Update(&domain.UpdateFolderParams{
+		Description:    &description,
+		ReleaseVersion: params.ReleaseVersion,
+	}); err != nil {
+		return nil, err
+	}
+
+	sourceTasks, err := f.taskStore.FindByFilter(ctx, &store.TaskFilterQuery{
+		FolderID:      source.ID,
+		CheckStatuses: params.CheckStatuses,
+		CheckResults:  params.CheckResults,
+		AssigneeIDs:   params.AssigneeIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	shift := time.Duration(params.TestEnvDateShiftDays) * 24 * time.Hour
+	clones := make([]*domain.Task, len(sourceTasks))
+	for i, task := range sourceTasks {
+		clones[i], err = task.CloneTo(newFolder.ID, params.CurrentUserID, shift)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sourceMembers, err := f.memberStore.FindByFolderIDWithUserInfo(ctx, source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	owner, err := domain.NewFolderMember(newFolder.ID, params.CurrentUserID, domain.FolderOwner)
+	if err != nil {
+		return nil, err
+	}
+	members := []*domain.FolderMember{owner}
+	for _, member := range sourceMembers {
+		if member.UserID == params.CurrentUserID {
+			continue
+		}
+		clone, err := domain.NewFolderMember(newFolder.ID, member.UserID, member.Role)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, clone)
+	}
+
+	notifications, err := f.clonedTasksNotifications(newFolder, clones)
+	if err != nil {
+		return nil, err
+	}
+
+	clonedTasksData := make([]any, len(clones))
+	for i, clone := range clones {
+		clonedTasksData[i] = webhookTaskData(clone, nil)
+	}
+
+	err = f.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := f.folderStore.Save(ctx, newFolder); err != nil {
+			return err
+		}
+		for _, member := range members {
+			if err := f.memberStore.Save(ctx, member); err != nil {
+				return err
+			}
+		}
+		if err := f.taskStore.SaveAll(ctx, clones); err != nil {
+			return err
+		}
+		if err := f.webhookService.Publish(ctx, domain.WebhookFolderCreated, []string{newFolder.ID}, webhookFolderData(newFolder, len(clones))); err != nil {
+			return err
+		}
+		if err := f.webhookService.Publish(ctx, domain.WebhookTaskCreated, []string{newFolder.ID}, clonedTasksData...); err != nil {
+			return err
+		}
+		return f.notificationService.Publish(ctx, notifications...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("db error while cloning folder: %w", err)
+	}
+
+	return &dto.FolderCreatedResponse{
+		FolderDataResponse: *mapFolderToFolderData(newFolder, int64(len(clones))),
+	}, nil
+}
+
+func (f *folerServiceImpl) clonedTasksNotifications(folder *domain.Folder, tasks []*domain.Task) ([]*domain.Notification, error) {
+	var assigneeIDs []string
+	taskIDsByAssignee := make(map[string][]string)
+	for _, task := range tasks {
+		if _, ok := taskIDsByAssignee[task.AssigneeID]; !ok {
+			assigneeIDs = append(assigneeIDs, task.AssigneeID)
+		}
+		taskIDsByAssignee[task.AssigneeID] = append(taskIDsByAssignee[task.AssigneeID], task.ID)
+	}
+
+	notifications := make([]*domain.Notification, len(assigneeIDs))
+	for i, assigneeID := range assigneeIDs {
+		notification, err := domain.NewNotification(domain.EventTasksAssigned, assigneeID, &domain.NotificationPayload{
+			FolderID:   folder.ID,
+			FolderName: folder.Name,
+			TaskIDs:    taskIDsByAssignee[assigneeID],
+		})
+		if err != nil {
+			return nil, err
+		}
+		notifications[i] = notification
+	}
+	return notifications, nil
+}
+
+func (f *folerServiceImpl) ListMembers(ctx context.Context, folderID string) ([]*dto.FolderMemberResponse, error) {
+	if err := f.isFolderExists(ctx, folderID); err != nil {
+		return nil, err
+	}
+
+	members, err := f.memberStore.FindByFolderIDWithUserInfo(ctx, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	data := make([]*dto.FolderMemberResponse, len(members))
+	for i, member := range members {
+		data[i] = &dto.FolderMemberResponse{
+			UserID:    member.UserID,
+			FullName:  fmt.Sprintf("%s %s", member.LastName, member.FirstName),
+			Role:      string(member.Role),
+			CreatedAt: member.CreatedAt,
+		}
+	}
+
+	return data, nil
+}
+
+func (f *folerServiceImpl) AddMember(ctx context.Context, params *FolderMemberParams) error {
+	if err := f.isFolderExists(ctx, params.FolderID); err != nil {
+		return err
+	}
+
+	ok, err := f.userStore.IsExists(ctx, params.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to check user existence: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: with id %s", store.ErrUserNotFound, params.UserID)
+	}
+
+	member, err := f.memberStore.Find(ctx, params.FolderID, params.UserID)
+	if err != nil && !errors.Is(err, store.ErrMemberNotFound) {
+		return fmt.Errorf("db error: %w", err)
+	}
+	if member != nil {
+		return f.changeRole(ctx, member, domain.FolderRole(params.Role))
+	}
+
+	newMember, err := domain.NewFolderMember(params.FolderID, params.UserID, domain.FolderRole(params.Role))
+	if err != nil {
+		return err
+	}
+
+	if err := f.memberStore.Save(ctx, newMember); err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return nil
+}
+
+func (f *folerServiceImpl) UpdateMember(ctx context.Context, params *FolderMemberParams) error {
+	member, err := f.memberStore.Find(ctx, params.FolderID, params.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrMemberNotFound) {
+			return fmt.Errorf("%w: user %s in folder %s", err, params.UserID, params.FolderID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return f.changeRole(ctx, member, domain.FolderRole(params.Role))
+}
+
+func (f *folerServiceImpl) RemoveMember(ctx context.Context, folderID, userID string) error {
+	member, err := f.memberStore.Find(ctx, folderID, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrMemberNotFound) {
+			return fmt.Errorf("%w: user %s in folder %s", err, userID, folderID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if member.Role == domain.FolderOwner {
+		if err := f.ensureAnotherOwner(ctx, folderID); err != nil {
+			return err
+		}
+	}
+
+	if err := f.memberStore.Delete(ctx, folderID, userID); err != nil {
+		if errors.Is(err, store.ErrMemberNotFound) {
+			return err
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return nil
+}
+
+func (f *folerServiceImpl) changeRole(ctx context.Context, member *domain.FolderMember, role domain.FolderRole) error {
+	if member.Role == domain.FolderOwner && role != domain.FolderOwner {
+		if err := f.ensureAnotherOwner(ctx, member.FolderID); err != nil {
+			return err
+		}
+	}
+
+	if err := member.ChangeRole(role); err != nil {
+		return err
+	}
+
+	if err := f.memberStore.Save(ctx, member); err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return nil
+}
+
+func (f *folerServiceImpl) ensureAnotherOwner(ctx context.Context, folderID string) error {
+	owners, err := f.memberStore.CountByRole(ctx, folderID, domain.FolderOwner)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+	if owners <= 1 {
+		return fmt.Errorf("%w: folder with ID: %s", ErrLastFolderOwner, folderID)
+	}
+	return nil
+}
+
+func (f *folerServiceImpl) isFolderExists(ctx context.Context, folderID string) error {
+	ok, err := f.folderStore.IsExists(ctx, folderID)
+	if err != nil {
+		return fmt.Errorf("failed to check folder existence: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: with id %s", store.ErrFolderNotFound, folderID)
+	}
+	return nil
 }
 
 func (f *folerServiceImpl) Details(ctx context.Context, folderId string) (*dto.FolderDetailsResponse, error) {
-	folder, err := f.folderStore.FindByID(ctx, folderId, store.WithCreator)
+	folder, err := f.folderStore.FindByID(ctx, folderId, store.WithCreator, store.WithOwner)
 	if err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) {
 			return nil, fmt.Errorf("%w: with ID: %s", err, folderId)
@@ -30,14 +362,105 @@ func (f *folerServiceImpl) Details(ctx context.Context, folderId string) (*dto.F
 		return nil, fmt.Errorf("db error: %w", err)
 	}
 
-	return &dto.FolderDetailsResponse{
-		Name:           folder.Name,
-		CreatedAt:      folder.CreatedAt,
-		AssigneePerson: fmt.Sprintf("%s %s", folder.Creator.LastName, folder.Creator.FirstName),
-	}, nil
+	response := &dto.FolderDetailsResponse{
+		Name:             folder.Name,
+		Description:      folder.Description,
+		ReleaseVersion:   folder.ReleaseVersion,
+		PlannedStartDate: folder.PlannedStartDate,
+		PlannedEndDate:   folder.PlannedEndDate,
+		OwnerID:          folder.OwnerID,
+		ReportTemplateID: folder.ReportTemplateID,
+		CreatedAt:        folder.CreatedAt,
+		AssigneePerson:   fmt.Sprintf("%s %s", folder.Creator.LastName, folder.Creator.FirstName),
+	}
+	if folder.Owner != nil {
+		response.OwnerPerson = fmt.Sprintf("%s %s", folder.Owner.LastName, folder.Owner.FirstName)
+	}
+
+	return response, nil
+}
+
+func (f *folerServiceImpl) Update(ctx context.Context, params *UpdateFolderParams) error {
+	folder, err := f.folderStore.FindByID(ctx, params.FolderID)
+	if err != nil {
+		if errors.Is(err, store.ErrFolderNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, params.FolderID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if params.OwnerID != nil && *params.OwnerID != "" {
+		ok, err := f.userStore.IsExists(ctx, *params.OwnerID)
+		if err != nil {
+			return fmt.Errorf("failed to check user existence: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: with id %s", store.ErrUserNotFound, *params.OwnerID)
+		}
+	}
+
+	if params.ReportTemplateID != nil && *params.ReportTemplateID != "" {
+		if _, err := f.templateStore.FindByID(ctx, *params.ReportTemplateID); err != nil {
+			if errors.Is(err, store.ErrTemplateNotFound) {
+				return fmt.Errorf("%w: with ID: %s", err, *params.ReportTemplateID)
+			}
+			return fmt.Errorf("db error: %w", err)
+		}
+	}
+
+	previousOwnerID := folder.OwnerID
+	if err := folder.Update(&domain.UpdateFolderParams{
+		Name:             params.Name,
+		Description:      params.Description,
+		ReleaseVersion:   params.ReleaseVersion,
+		PlannedStartDate: params.PlannedStartDate,
+		PlannedEndDate:   params.PlannedEndDate,
+		OwnerID:          params.OwnerID,
+		ReportTemplateID: params.ReportTemplateID,
+	}); err != nil {
+		return err
+	}
+
+	return f.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := f.folderStore.Save(ctx, folder); err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+
+		if params.OwnerID == nil {
+			return nil
+		}
+		return f.transferOwnership(ctx, folder.ID, previousOwnerID, *params.OwnerID)
+	})
 }
 
-func (f *folerServiceImpl) Delete(ctx context.Context, folderID string) error {
+func (f *folerServiceImpl) transferOwnership(ctx context.Context, folderID string, previousOwnerID *string, ownerID string) error {
+	if err := f.AddMember(ctx, &FolderMemberParams{
+		FolderID: folderID,
+		UserID:   ownerID,
+		Role:     string(domain.FolderOwner),
+	}); err != nil {
+		return err
+	}
+
+	if previousOwnerID == nil || *previousOwnerID == ownerID {
+		return nil
+	}
+
+	previous, err := f.memberStore.Find(ctx, folderID, *previousOwnerID)
+	if err != nil {
+		if errors.Is(err, store.ErrMemberNotFound) {
+			return nil
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+	if previous.Role != domain.FolderOwner {
+		return nil
+	}
+
+	return f.changeRole(ctx, previous, domain.FolderEditor)
+}
+
+func (f *folerServiceImpl) Delete(ctx context.Context, folderID, currentUserID string) error {
 	fodler, err := f.folderStore.FindByID(ctx, folderID)
 	if err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) {
@@ -46,9 +469,47 @@ func (f *folerServiceImpl) Delete(ctx context.Context, folderID string) error {
 		return fmt.Errorf("db error: %w", err)
 	}
 
+	tasks, err := f.taskStore.FindByFilter(ctx, &store.TaskFilterQuery{FolderID: folderID})
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	var assigneeIDs []string
+	taskCounts := make(map[string]int)
+	for _, task := range tasks {
+		if task.AssigneeID == currentUserID {
+			continue
+		}
+		if taskCounts[task.AssigneeID] == 0 {
+			assigneeIDs = append(assigneeIDs, task.AssigneeID)
+		}
+		taskCounts[task.AssigneeID]++
+	}
+
+	notifications := make([]*domain.Notification, len(assigneeIDs))
+	for i, assigneeID := range assigneeIDs {
+		notifications[i], err = domain.NewNotification(domain.EventFolderDeleted, assigneeID, &domain.NotificationPayload{
+			FolderID:   fodler.ID,
+			FolderName: fodler.Name,
+			TaskCount:  taskCounts[assigneeID],
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	fodler.Delete()
 
-	if err := f.folderStore.Save(ctx, fodler); err != nil {
+	err = f.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := f.folderStore.Save(ctx, fodler); err != nil {
+			return err
+		}
+		if err := f.webhookService.Publish(ctx, domain.WebhookFolderDeleted, []string{fodler.ID}, webhookFolderData(fodler, len(tasks))); err != nil {
+			return err
+		}
+		return f.notificationService.Publish(ctx, notifications...)
+	})
+	if err != nil {
 		return fmt.Errorf("db error: %w", err)
 	}
 
@@ -60,44 +521,81 @@ func (f *folerServiceImpl) Save(ctx context.Context, name, userId string) (*dto.
 	if err != nil {
 		return nil, err
 	}
-	if err := f.folderStore.Save(ctx, newFolder); err != nil {
+	owner, err := domain.NewFolderMember(newFolder.ID, userId, domain.FolderOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := f.folderStore.Save(ctx, newFolder); err != nil {
+			return err
+		}
+		if err := f.memberStore.Save(ctx, owner); err != nil {
+			return err
+		}
+		return f.webhookService.Publish(ctx, domain.WebhookFolderCreated, []string{newFolder.ID}, webhookFolderData(newFolder, 0))
+	})
+	if err != nil {
 		return nil, fmt.Errorf("%s: failed to save folder", err.Error())
 	}
 
 	response := &dto.FolderCreatedResponse{
-		FolderDataResponse: dto.FolderDataResponse{
-			Name:      newFolder.Name,
-			CreatedAt: newFolder.CreatedAt,
-			Id:        newFolder.ID,
-			TaskCount: 0,
-		},
+		FolderDataResponse: *mapFolderToFolderData(newFolder, 0),
 	}
 
 	return response, nil
 }
 
-func (f *folerServiceImpl) Search(ctx context.Context, page int, pageSize int, query string) (*dto.FolderSearchResponse, error) {
-	result, count, err := f.folderStore.Search(ctx, page, pageSize, query)
+func (f *folerServiceImpl) Search(ctx context.Context, params *SearchFoldersParams) (*dto.FolderSearchResponse, error) {
+	query := &store.SearchFoldersQuery{
+		Page:     params.Page,
+		PageSize: params.PageSize,
+		Query:    params.Query,
+	}
+	if !params.Principal.Has(domain.PermFolderRead) {
+		query.MemberID = params.Principal.UserID
+	}
+
+	result, count, err := f.folderStore.Search(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("%s: error while searching", err.Error())
 	}
 
 	data := make([]*dto.FolderDataResponse, len(result))
 	for i, folder := range result {
-		data[i] = &dto.FolderDataResponse{
-			Name:      folder.Name,
-			CreatedAt: folder.CreatedAt,
-			Id:        folder.ID,
-			TaskCount: int(folder.TaskCount),
-		}
+		data[i] = mapFolderToFolderData(&folder.Folder, folder.TaskCount)
 	}
 
 	return &dto.FolderSearchResponse{
 		Data:       data,
-		Pagination: store.CalculatePaginationResult(page, pageSize, count),
+		Pagination: store.CalculatePaginationResult(params.Page, params.PageSize, count),
 	}, nil
 }
 
-func NewFolderService(folderStore store.FolderStore) FolderService {
-	return &folerServiceImpl{folderStore: folderStore}
+func mapFolderToFolderData(folder *domain.Folder, taskCount int64) *dto.FolderDataResponse {
+	return &dto.FolderDataResponse{
+		Name:             folder.Name,
+		Description:      folder.Description,
+		ReleaseVersion:   folder.ReleaseVersion,
+		PlannedStartDate: folder.PlannedStartDate,
+		PlannedEndDate:   folder.PlannedEndDate,
+		OwnerID:          folder.OwnerID,
+		ReportTemplateID: folder.ReportTemplateID,
+		CreatedAt:        folder.CreatedAt,
+		Id:               folder.ID,
+		TaskCount:        int(taskCount),
+	}
+}
+
+func NewFolderService(deps *FolderServiceDeps) FolderService {
+	return &folerServiceImpl{
+		folderStore:         deps.FolderStore,
+		memberStore:         deps.MemberStore,
+		userStore:           deps.UserStore,
+		taskStore:           deps.TaskStore,
+		templateStore:       deps.TemplateStore,
+		txManager:           deps.TxManager,
+		notificationService: deps.NotificationService,
+		webhookService:      deps.WebhookService,
+	}
 }
@@ -0,0 +1,561 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/notification"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+const (
+	notificationBatchSize = 20
+	notificationLease     = 5 * time.Minute
+)
+
+type ListInboxParams struct {
+	UserID     string
+	UnreadOnly bool
+	Page       int
+	PageSize   int
+}
+
+type UpdateNotificationSettingsParams struct {
+	UserID          string
+	Events          map[string]string
+	QuietHoursStart *string
+	QuietHoursEnd   *string
+	Timezone        *string
+	DigestHour      *int
+}
+
+type NotificationService interface {
+	Publish(ctx context.Context, notifications ...*domain.Notification) error
+	ListInbox(ctx context.Context, params *ListInboxParams) (*dto.InboxListResponse, error)
+	UnreadCount(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID, itemID string) error
+	MarkAllRead(ctx context.Context, userID string) error
+	GetSettings(ctx context.Context, userID string) (*dto.NotificationSettingsResponse, error)
+	UpdateSettings(ctx context.Context, params *UpdateNotificationSettingsParams) (*dto.NotificationSettingsResponse, error)
+	ProcessDue(ctx context.Context) error
+	SendDigests(ctx context.Context) error
+	ListDeadLetters(ctx context.Context, page, pageSize int) (*dto.NotificationListResponse, error)
+	Retry(ctx context.Context, notificationID string) (*dto.NotificationResponse, error)
+}
+
+type NotificationServiceDeps struct {
+	NotificationStore store.NotificationStore
+	InboxStore        store.InboxStore
+	SettingsStore     store.NotificationSettingsStore
+	UserStore         store.UserStore
+	TaskStore         store.TaskStore
+	FolderStore       store.FolderStore
+	TxManager         store.TxManager
+	Notifier          notification.Notifier
+	MaxAttempts       int
+}
+
+type notificationServiceImpl struct {
+	notificationStore store.NotificationStore
+	inboxStore        store.InboxStore
+	settingsStore     store.NotificationSettingsStore
+	userStore         store.UserStore
+	taskStore         store.TaskStore
+	folderStore       store.FolderStore
+	txManager         store.TxManager
+	notifier          notification.Notifier
+	maxAttempts       int
+}
+
+// Publish must run in the transaction of the change that caused the notifications.
+func (n *notificationServiceImpl) Publish(ctx context.Context, notifications ...*domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	items := make([]*domain.InboxItem, len(notifications))
+	for i, notification := range notifications {
+		item, err := domain.NewInboxItem(notification)
+		if err != nil {
+			return err
+		}
+		items[i] = item
+	}
+
+	if err := n.notificationStore.SaveAll(ctx, notifications); err != nil {
+		return err
+	}
+	return n.inboxStore.SaveAll(ctx, items)
+}
+
+func (n *notificationServiceImpl) ListInbox(ctx context.Context, params *ListInboxParams) (*dto.InboxListResponse, error) {
+	items, count, err := n.inboxStore.FindByUser(ctx, &store.InboxQuery{
+		UserID:     params.UserID,
+		UnreadOnly: params.UnreadOnly,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	unread, err := n.UnreadCount(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := i18n.FromContext(ctx)
+	data := make([]*dto.InboxItemResponse, len(items))
+	for i, item := range items {
+		payload, err := item.DecodePayload()
+		if err != nil {
+			return nil, err
+		}
+		data[i] = &dto.InboxItemResponse{
+			ID:        item.ID,
+			Type:      string(item.Event),
+			TaskID:    item.TaskID,
+			FolderID:  item.FolderID,
+			Message:   notificationMessage(locale, item.Event, item.UserID, payload),
+			Read:      item.Read,
+			ReadAt:    item.ReadAt,
+			CreatedAt: item.CreatedAt,
+		}
+	}
+
+	return &dto.InboxListResponse{
+		Data:        data,
+		UnreadCount: unread,
+		Pagination:  store.CalculatePaginationResult(params.Page, params.PageSize, count),
+	}, nil
+}
+
+func notificationMessage(locale i18n.Locale, event domain.NotificationEvent, recipientID string, payload *domain.NotificationPayload) string {
+	switch event {
+	case domain.EventTasksAssigned:
+		return i18n.T(locale, "inbox.tasks_assigned", len(payload.TaskIDs), payload.FolderName)
+	case domain.EventTaskReassigned:
+		if recipientID == payload.PreviousAssigneeID {
+			return i18n.T(locale, "inbox.task_reassigned.removed", payload.SoftName)
+		}
+		return i18n.T(locale, "inbox.task_reassigned.assigned", payload.SoftName)
+	case domain.EventTaskDeleted:
+		return i18n.T(locale, "inbox.task_deleted", payload.SoftName, payload.FolderName)
+	case domain.EventFolderDeleted:
+		return i18n.T(locale, "inbox.folder_deleted", payload.FolderName, payload.TaskCount)
+	case domain.EventTaskMentioned:
+		return i18n.T(locale, "inbox.task_mentioned."+payload.MentionSource, payload.ActorName, payload.SoftName)
+	default:
+		return i18n.T(locale, "inbox."+string(event), payload.SoftName)
+	}
+}
+
+func (n *notificationServiceImpl) UnreadCount(ctx context.Context, userID string) (int64, error) {
+	count, err := n.inboxStore.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("db error: %w", err)
+	}
+	return count, nil
+}
+
+func (n *notificationServiceImpl) MarkRead(ctx context.Context, userID, itemID string) error {
+	if err := n.inboxStore.MarkRead(ctx, userID, itemID, time.Now().UTC()); err != nil {
+		if errors.Is(err, store.ErrInboxItemNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, itemID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+	return nil
+}
+
+func (n *notificationServiceImpl) MarkAllRead(ctx context.Context, userID string) error {
+	if _, err := n.inboxStore.MarkAllRead(ctx, userID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+	return nil
+}
+
+func (n *notificationServiceImpl) ProcessDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		notifications, err := n.notificationStore.ClaimDue(ctx, time.Now().UTC(), notificationLease, notificationBatchSize)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		for _, notification := range notifications {
+			n.process(ctx, notification)
+		}
+	}
+	return nil
+}
+
+func (n *notificationServiceImpl) process(ctx context.Context, entry *domain.Notification) {
+	settings, err := n.settingsFor(ctx, entry.RecipientID)
+	if err == nil && deferDelivery(entry, settings, time.Now().UTC()) {
+		n.save(ctx, entry)
+		return
+	}
+	if err == nil {
+		err = n.dispatch(ctx, entry, n.pendingNotifier(entry.DeliveredChannels))
+		recordDelivered(err, entry)
+	}
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		entry.MarkSent(now)
+	case isPermanentNotificationError(err):
+		log.Printf("NOTIFICATION_ERROR: dropping %s to dead letters: %v", entry.ID, err)
+		entry.MarkDead(err.Error(), now)
+	default:
+		log.Printf("NOTIFICATION_ERROR: attempt %d of %s failed: %v", entry.Attempts, entry.ID, err)
+		entry.MarkFailed(err.Error(), now, n.maxAttempts)
+	}
+
+	n.save(ctx, entry)
+}
+
+func deferDelivery(entry *domain.Notification, settings *domain.NotificationSettings, now time.Time) bool {
+	switch settings.Mode(entry.Event) {
+	case domain.DeliveryOff:
+		entry.MarkSkipped(now)
+		return true
+	case domain.DeliveryDigest:
+		entry.HoldForDigest(now)
+		return true
+	}
+
+	if until, quiet := settings.QuietUntil(now); quiet {
+		entry.Postpone(until, now)
+		return true
+	}
+	return false
+}
+
+func (n *notificationServiceImpl) pendingNotifier(delivered []string) notification.Notifier {
+	if filter, ok := n.notifier.(notification.ChannelFilter); ok && len(delivered) > 0 {
+		return filter.Except(delivered)
+	}
+	return n.notifier
+}
+
+func recordDelivered(err error, entries ...*domain.Notification) {
+	var partial *notification.PartialDeliveryError
+	if !errors.As(err, &partial) {
+		return
+	}
+	for _, entry := range entries {
+		entry.MarkDelivered(partial.Delivered...)
+	}
+}
+
+func (n *notificationServiceImpl) save(ctx context.Context, entry *domain.Notification) {
+	if err := n.notificationStore.Save(ctx, entry); err != nil {
+		log.Printf("NOTIFICATION_ERROR: couldn't save %s: %v", entry.ID, err)
+	}
+}
+
+func (n *notificationServiceImpl) SendDigests(ctx context.Context) error {
+	recipients, err := n.notificationStore.FindDigestRecipients(ctx)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	for _, recipientID := range recipients {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := n.sendDigest(ctx, recipientID, time.Now().UTC()); err != nil {
+			log.Printf("NOTIFICATION_ERROR: couldn't send digest to %s: %v", recipientID, err)
+		}
+	}
+	return nil
+}
+
+func (n *notificationServiceImpl) sendDigest(ctx context.Context, recipientID string, now time.Time) error {
+	settings, err := n.settingsFor(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	if _, quiet := settings.QuietUntil(now); quiet || !settings.DigestDue(now) {
+		return nil
+	}
+
+	user, err := n.userStore.FindById(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	locale, ok := i18n.Parse(user.EffectiveLocale())
+	if !ok {
+		locale = i18n.DefaultLocale
+	}
+
+	entries, err := n.notificationStore.ClaimDigest(ctx, recipientID, now, notificationLease)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	items := make([]notification.DigestEntry, len(entries))
+	for i, entry := range entries {
+		payload, err := entry.DecodePayload()
+		if err != nil {
+			return err
+		}
+		items[i] = notification.DigestEntry{Message: notificationMessage(locale, entry.Event, recipientID, payload)}
+		if len(payload.TaskIDs) == 1 && entry.Event != domain.EventTaskDeleted {
+			items[i].TaskID = payload.TaskIDs[0]
+		}
+	}
+
+	if err := n.pendingNotifier(commonChannels(entries)).SendDigest(user, items); err != nil {
+		recordDelivered(err, entries...)
+		for _, entry := range entries {
+			n.save(ctx, entry)
+		}
+		return err
+	}
+
+	return n.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, entry := range entries {
+			entry.MarkSent(now)
+			if err := n.notificationStore.Save(ctx, entry); err != nil {
+				return err
+			}
+		}
+		settings.RecordDigest(now)
+		return n.settingsStore.Save(ctx, settings)
+	})
+}
+
+func commonChannels(entries []*domain.Notification) []string {
+	common := entries[0].DeliveredChannels
+	for _, entry := range entries[1:] {
+		common = slices.DeleteFunc(slices.Clone(common), func(channel string) bool {
+			return !slices.Contains(entry.DeliveredChannels, channel)
+		})
+	}
+	return common
+}
+
+func (n *notificationServiceImpl) GetSettings(ctx context.Context, userID string) (*dto.NotificationSettingsResponse, error) {
+	settings, err := n.settingsFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapSettingsToResponse(settings), nil
+}
+
+func (n *notificationServiceImpl) UpdateSettings(ctx context.Context, params *UpdateNotificationSettingsParams) (*dto.NotificationSettingsResponse, error) {
+	settings, err := n.settingsFor(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	modes := make(map[domain.NotificationEvent]domain.DeliveryMode, len(params.Events))
+	for event, mode := range params.Events {
+		modes[domain.NotificationEvent(event)] = domain.DeliveryMode(mode)
+	}
+
+	err = settings.Update(&domain.UpdateNotificationSettingsParams{
+		Modes:           modes,
+		QuietHoursStart: params.QuietHoursStart,
+		QuietHoursEnd:   params.QuietHoursEnd,
+		Timezone:        params.Timezone,
+		DigestHour:      params.DigestHour,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := n.settingsStore.Save(ctx, settings); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapSettingsToResponse(settings), nil
+}
+
+func (n *notificationServiceImpl) settingsFor(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	settings, err := n.settingsStore.FindByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrSettingsNotFound) {
+			return domain.DefaultNotificationSettings(userID), nil
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+	return settings, nil
+}
+
+func (n *notificationServiceImpl) dispatch(ctx context.Context, entry *domain.Notification, notifier notification.Notifier) error {
+	payload, err := entry.DecodePayload()
+	if err != nil {
+		return err
+	}
+
+	user, err := n.userStore.FindById(ctx, entry.RecipientID)
+	if err != nil {
+		return err
+	}
+
+	switch entry.Event {
+	case domain.EventTaskAssigned:
+		task, err := n.taskStore.FindById(ctx, payload.TaskIDs[0])
+		if err != nil {
+			return err
+		}
+		return notifier.NotifyAboutNewTask(user, task)
+	case domain.EventTasksAssigned:
+		folder, err := n.folderStore.FindByID(ctx, payload.FolderID)
+		if err != nil {
+			return err
+		}
+		tasks, err := n.taskStore.FindByIDs(ctx, payload.TaskIDs)
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return fmt.Errorf("%w: none of the assigned tasks exist anymore", store.ErrTaskNotFound)
+		}
+		return notifier.NotifyAboutNewTasks(user, folder, tasks)
+	case domain.EventResultSubmitted:
+		task, err := n.taskStore.FindById(ctx, payload.TaskIDs[0])
+		if err != nil {
+			return err
+		}
+		return notifier.NotifyAboutResult(user, task)
+	case domain.EventTaskReassigned:
+		task, err := n.taskStore.FindById(ctx, payload.TaskIDs[0])
+		if err != nil {
+			return err
+		}
+		return notifier.NotifyAboutReassignment(user, task, user.ID != payload.PreviousAssigneeID)
+	case domain.EventTaskUpdated:
+		task, err := n.taskStore.FindById(ctx, payload.TaskIDs[0])
+		if err != nil {
+			return err
+		}
+		return notifier.NotifyAboutTaskUpdate(user, task, payload.ChangedFields)
+	case domain.EventTaskDeleted:
+		return notifier.NotifyAboutTaskDeletion(user, &notification.DeletedTask{
+			SoftName:   payload.SoftName,
+			RequestID:  payload.RequestID,
+			FolderName: payload.FolderName,
+		})
+	case domain.EventFolderDeleted:
+		return notifier.NotifyAboutFolderDeletion(user, payload.FolderName, payload.TaskCount)
+	case domain.EventTaskMentioned:
+		task, err := n.taskStore.FindById(ctx, payload.TaskIDs[0])
+		if err != nil {
+			return err
+		}
+		return notifier.NotifyAboutMention(user, task, payload.ActorName, domain.MentionSource(payload.MentionSource))
+	default:
+		return fmt.Errorf("%w: unknown notification event '%s'", domain.ErrValidation, entry.Event)
+	}
+}
+
+func isPermanentNotificationError(err error) bool {
+	return errors.Is(err, store.ErrUserNotFound) ||
+		errors.Is(err, store.ErrTaskNotFound) ||
+		errors.Is(err, store.ErrFolderNotFound) ||
+		errors.Is(err, domain.ErrValidation)
+}
+
+func (n *notificationServiceImpl) ListDeadLetters(ctx context.Context, page, pageSize int) (*dto.NotificationListResponse, error) {
+	notifications, count, err := n.notificationStore.FindDead(ctx, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	data := make([]*dto.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		data[i] = mapNotificationToResponse(notification)
+	}
+
+	return &dto.NotificationListResponse{
+		Data:       data,
+		Pagination: store.CalculatePaginationResult(page, pageSize, count),
+	}, nil
+}
+
+func (n *notificationServiceImpl) Retry(ctx context.Context, notificationID string) (*dto.NotificationResponse, error) {
+	notification, err := n.notificationStore.FindByID(ctx, notificationID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotificationNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, notificationID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if err := notification.Requeue(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if err := n.notificationStore.Save(ctx, notification); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapNotificationToResponse(notification), nil
+}
+
+func publishNotification(ctx context.Context, notificationService NotificationService, event domain.NotificationEvent, recipientID string, payload *domain.NotificationPayload) error {
+	notification, err := domain.NewNotification(event, recipientID, payload)
+	if err != nil {
+		return err
+	}
+	return notificationService.Publish(ctx, notification)
+}
+
+func mapNotificationToResponse(notification *domain.Notification) *dto.NotificationResponse {
+	return &dto.NotificationResponse{
+		ID:            notification.ID,
+		Event:         string(notification.Event),
+		RecipientID:   notification.RecipientID,
+		Status:        string(notification.Status),
+		Attempts:      notification.Attempts,
+		LastError:     notification.LastError,
+		NextAttemptAt: notification.NextAttemptAt,
+		SentAt:        notification.SentAt,
+		CreatedAt:     notification.CreatedAt,
+		UpdatedAt:     notification.UpdatedAt,
+	}
+}
+
+func mapSettingsToResponse(settings *domain.NotificationSettings) *dto.NotificationSettingsResponse {
+	events := make(map[string]string, len(domain.AllNotificationEvents))
+	for _, event := range domain.AllNotificationEvents {
+		events[string(event)] = string(settings.Mode(event))
+	}
+
+	return &dto.NotificationSettingsResponse{
+		Events:          events,
+		QuietHoursStart: settings.QuietHoursStart,
+		QuietHoursEnd:   settings.QuietHoursEnd,
+		Timezone:        settings.Timezone,
+		DigestHour:      settings.DigestHour,
+		LastDigestAt:    settings.LastDigestAt,
+	}
+}
+
+func NewNotificationService(deps *NotificationServiceDeps) NotificationService {
+	return &notificationServiceImpl{
+		notificationStore: deps.NotificationStore,
+		inboxStore:        deps.InboxStore,
+		settingsStore:     deps.SettingsStore,
+		userStore:         deps.UserStore,
+		taskStore:         deps.TaskStore,
+		folderStore:       deps.FolderStore,
+		txManager:         deps.TxManager,
+		notifier:          deps.Notifier,
+		maxAttempts:       deps.MaxAttempts,
+	}
+}
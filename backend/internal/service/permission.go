@@ -0,0 +1,79 @@
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+)
+
+const (
+	defaultRoleKey = "default"
+	adminRoleKey   = "admin"
+)
+
+type Principal struct {
+	UserID      string
+	IsAdmin     bool
+	Permissions []domain.Permission
+}
+
+func (p *Principal) Has(permission domain.Permission) bool {
+	return slices.Contains(p.Permissions, permission)
+}
+
+func principalOf(user *domain.User, policy PermissionPolicy) *Principal {
+	return &Principal{
+		UserID:      user.ID,
+		IsAdmin:     IsAdminRole(user.Roles),
+		Permissions: policy.Resolve(user.Roles),
+	}
+}
+
+func IsAdminRole(roles []string) bool {
+	return slices.ContainsFunc(roles, func(role string) bool {
+		return strings.EqualFold(role, adminRoleKey)
+	})
+}
+
+type PermissionPolicy interface {
+	Resolve(roles []string) []domain.Permission
+}
+
+type permissionPolicyImpl struct {
+	rolePermissions map[string][]domain.Permission
+}
+
+func (p *permissionPolicyImpl) Resolve(roles []string) []domain.Permission {
+	resolved := slices.Clone(p.rolePermissions[defaultRoleKey])
+	for _, role := range roles {
+		for _, permission := range p.rolePermissions[strings.ToLower(role)] {
+			if !slices.Contains(resolved, permission) {
+				resolved = append(resolved, permission)
+			}
+		}
+	}
+	return resolved
+}
+
+func NewPermissionPolicy(rolePermissions map[string][]string) (PermissionPolicy, error) {
+	policy := &permissionPolicyImpl{rolePermissions: make(map[string][]domain.Permission, len(rolePermissions))}
+
+	for role, names := range rolePermissions {
+		var permissions []domain.Permission
+		for _, name := range names {
+			if name == "*" {
+				permissions = slices.Clone(domain.AllPermissions)
+				break
+			}
+			permission, err := domain.ParsePermission(name)
+			if err != nil {
+				return nil, err
+			}
+			permissions = append(permissions, permission)
+		}
+		policy.rolePermissions[strings.ToLower(role)] = permissions
+	}
+
+	return policy, nil
+}
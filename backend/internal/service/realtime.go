@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+const (
+	realtimeSubscriberBuffer = 64
+	realtimeRetryMinBackoff  = time.Second
+	realtimeRetryMaxBackoff  = 30 * time.Second
+)
+
+type RealtimeService interface {
+	Run(ctx context.Context) error
+	Subscribe(ctx context.Context, topic, lastEventID string) (<-chan *store.StreamEvent, error)
+	PublishTaskEvent(ctx context.Context, event domain.WebhookEvent, task, previous *domain.Task, changedFields []string)
+}
+
+type realtimeSubscriber struct {
+	events chan *store.StreamEvent
+}
+
+type realtimeServiceImpl struct {
+	eventStream store.EventStream
+
+	mu          sync.Mutex
+	subscribers map[string]map[*realtimeSubscriber]struct{}
+}
+
+func FolderTopic(folderID string) string {
+	return "folder:" + folderID
+}
+
+func UserTopic(userID string) string {
+	return "user:" + userID
+}
+
+// Run dispatches events from every instance to local subscribers, resubscribing with backoff on failure.
+func (s *realtimeServiceImpl) Run(ctx context.Context) error {
+	backoff := realtimeRetryMinBackoff
+	for ctx.Err() == nil {
+		events, err := s.eventStream.Subscribe(ctx)
+		if err != nil {
+			log.Printf("REALTIME_ERROR: couldn't subscribe to events, retrying in %s: %v", backoff, err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, realtimeRetryMaxBackoff)
+			continue
+		}
+
+		backoff = realtimeRetryMinBackoff
+		for event := range events {
+			s.dispatch(event)
+		}
+	}
+	return nil
+}
+
+// Subscribe replays the events after lastEventID, then follows live ones; slow subscribers are dropped.
+func (s *realtimeServiceImpl) Subscribe(ctx context.Context, topic, lastEventID string) (<-chan *store.StreamEvent, error) {
+	if lastEventID != "" {
+		if _, _, ok := parseEventID(lastEventID); !ok {
+			return nil, fmt.Errorf("%w: invalid Last-Event-ID '%s'", domain.ErrValidation, lastEventID)
+		}
+	}
+
+	subscriber := &realtimeSubscriber{events: make(chan *store.StreamEvent, realtimeSubscriberBuffer)}
+	s.register(topic, subscriber)
+
+	var history []*store.StreamEvent
+	if lastEventID != "" {
+		var err error
+		history, err = s.eventStream.Replay(ctx, topic, lastEventID)
+		if err != nil {
+			s.unregister(topic, subscriber)
+			return nil, err
+		}
+	}
+
+	out := make(chan *store.StreamEvent)
+	go func() {
+		defer close(out)
+		defer s.unregister(topic, subscriber)
+
+		send := func(event *store.StreamEvent) bool {
+			select {
+			case out <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		replayed := make(map[string]struct{}, len(history))
+		for _, event := range history {
+			if !send(event) {
+				return
+			}
+			replayed[event.ID] = struct{}{}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscriber.events:
+				if !ok {
+					return
+				}
+				if _, ok := replayed[event.ID]; ok {
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// PublishTaskEvent is best effort; moved and reassigned tasks are also announced on the topics they left.
+func (s *realtimeServiceImpl) PublishTaskEvent(ctx context.Context, event domain.WebhookEvent, task, previous *domain.Task, changedFields []string) {
+	data, err := json.Marshal(webhookTaskData(task, changedFields))
+	if err != nil {
+		log.Printf("REALTIME_ERROR: couldn't encode task %s: %v", task.ID, err)
+		return
+	}
+
+	topics := []string{FolderTopic(task.FolderID), UserTopic(task.AssigneeID)}
+	if previous != nil {
+		if previous.FolderID != task.FolderID {
+			topics = append(topics, FolderTopic(previous.FolderID))
+		}
+		if previous.AssigneeID != task.AssigneeID {
+			topics = append(topics, UserTopic(previous.AssigneeID))
+		}
+	}
+
+	for _, topic := range topics {
+		if _, err := s.eventStream.Publish(ctx, topic, string(event), data); err != nil {
+			log.Printf("REALTIME_ERROR: couldn't publish %s for task %s to %s: %v", event, task.ID, topic, err)
+		}
+	}
+}
+
+func (s *realtimeServiceImpl) dispatch(event *store.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscriber := range s.subscribers[event.Topic] {
+		select {
+		case subscriber.events <- event:
+		default:
+			close(subscriber.events)
+			delete(s.subscribers[event.Topic], subscriber)
+		}
+	}
+	if len(s.subscribers[event.Topic]) == 0 {
+		delete(s.subscribers, event.Topic)
+	}
+}
+
+func (s *realtimeServiceImpl) register(topic string, subscriber *realtimeSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers[topic] == nil {
+		s.subscribers[topic] = make(map[*realtimeSubscriber]struct{})
+	}
+	s.subscribers[topic][subscriber] = struct{}{}
+}
+
+func (s *realtimeServiceImpl) unregister(topic string, subscriber *realtimeSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[topic][subscriber]; !ok {
+		return
+	}
+	delete(s.subscribers[topic], subscriber)
+	if len(s.subscribers[topic]) == 0 {
+		delete(s.subscribers, topic)
+	}
+}
+
+func parseEventID(id string) (uint64, uint64, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+func NewRealtimeService(eventStream store.EventStream) RealtimeService {
+	return &realtimeServiceImpl{
+		eventStream: eventStream,
+		subscribers: make(map[string]map[*realtimeSubscriber]struct{}),
+	}
+}
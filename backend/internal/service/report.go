@@ -5,12 +5,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/i18n"
 	"github.com/pesos228/bug-tracker/internal/store"
 )
 
+type ReportFormat string
+
+const (
+	FormatXLSX     ReportFormat = "xlsx"
+	FormatPDF      ReportFormat = "pdf"
+	FormatCSV      ReportFormat = "csv"
+	FormatJSON     ReportFormat = "json"
+	FormatMarkdown ReportFormat = "md"
+)
+
+var reportFormats = []ReportFormat{FormatXLSX, FormatPDF, FormatCSV, FormatJSON, FormatMarkdown}
+
 type TaskReportRow struct {
 	SoftName          string
 	RequestID         string
@@ -23,69 +41,478 @@ type TaskReportRow struct {
 	Comment           string
 }
 
+type ReportMeta struct {
+	FolderName       string
+	ReleaseVersion   string
+	CreatorPerson    string
+	CreatedAt        time.Time
+	PlannedStartDate *time.Time
+	PlannedEndDate   *time.Time
+	GeneratedAt      time.Time
+}
+
 type ReportGenerator interface {
-	Generate(tasks []*TaskReportRow) (*bytes.Buffer, error)
+	Format() ReportFormat
+	ContentType() string
+	Generate(layout *ReportLayout, meta *ReportMeta, tasks []*TaskReportRow) (*bytes.Buffer, error)
+}
+
+type TaskRowSource func(yield func(task *TaskReportRow) error) error
+
+type StreamingReportGenerator interface {
+	Stream(w io.Writer, layout *ReportLayout, meta *ReportMeta, tasks TaskRowSource) error
+}
+
+type ReportSection struct {
+	Meta  *ReportMeta
+	Tasks []*TaskReportRow
+}
+
+type SummaryReportGenerator interface {
+	ContentType() string
+	GenerateSummary(layout *ReportLayout, sections []*ReportSection) (*bytes.Buffer, error)
+}
+
+type CreateReportParams struct {
+	FolderID   string
+	Format     ReportFormat
+	TemplateID string
+	TaskFilter *domain.ReportFilter
+}
+
+type SummaryReportParams struct {
+	FolderIDs  []string
+	From       *time.Time
+	To         *time.Time
+	TemplateID string
+	TaskFilter *domain.ReportFilter
+	Principal  *Principal
 }
 
 type ReportData struct {
-	FileName string
-	Data     *bytes.Buffer
+	FileName    string
+	ContentType string
+	Data        *bytes.Buffer
+}
+
+type ReportStream struct {
+	FileName    string
+	ContentType string
+	render      func(w io.Writer) error
+}
+
+func (s *ReportStream) Render(w io.Writer) error {
+	return s.render(w)
 }
 
 type ReportService interface {
-	Create(ctx context.Context, folderID string) (*ReportData, error)
+	Open(ctx context.Context, params *CreateReportParams) (*ReportStream, error)
+	Create(ctx context.Context, params *CreateReportParams) (*ReportData, error)
+	CreateSummary(ctx context.Context, params *SummaryReportParams) (*ReportData, error)
+	NegotiateFormat(accept string) (ReportFormat, error)
+	SupportsFormat(format ReportFormat) bool
+	ValidateSummaryParams(params *SummaryReportParams) error
 }
 
+const maxSummaryFolders = 20
+
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
 type reportServiceImpl struct {
-	folderStore     store.FolderStore
-	taskStore       store.TaskStore
-	reportGenerator ReportGenerator
+	folderStore      store.FolderStore
+	taskStore        store.TaskStore
+	templateStore    store.ReportTemplateStore
+	generators       map[ReportFormat]ReportGenerator
+	summaryGenerator SummaryReportGenerator
+	defaultFormat    ReportFormat
 }
 
-func (r *reportServiceImpl) Create(ctx context.Context, folderID string) (*ReportData, error) {
-	folder, err := r.folderStore.FindByID(ctx, folderID)
+func (r *reportServiceImpl) Create(ctx context.Context, params *CreateReportParams) (*ReportData, error) {
+	stream, err := r.Open(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := stream.Render(buffer); err != nil {
+		return nil, err
+	}
+
+	return &ReportData{
+		FileName:    stream.FileName,
+		ContentType: stream.ContentType,
+		Data:        buffer,
+	}, nil
+}
+
+func (r *reportServiceImpl) Open(ctx context.Context, params *CreateReportParams) (*ReportStream, error) {
+	format := params.Format
+	if format == "" {
+		format = r.defaultFormat
+	}
+
+	generator, ok := r.generators[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, format)
+	}
+
+	if params.TaskFilter != nil {
+		if err := params.TaskFilter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	folder, err := r.folderStore.FindByID(ctx, params.FolderID, store.WithCreator)
 	if err != nil {
 		if errors.Is(err, store.ErrFolderNotFound) {
-			return nil, fmt.Errorf("%w: with ID: %s", err, folderID)
+			return nil, fmt.Errorf("%w: with ID: %s", err, params.FolderID)
 		}
 		return nil, fmt.Errorf("db error: %w", err)
 	}
 
-	tasks, err := r.taskStore.FindByFolderIdWithUserInfo(ctx, folderID)
+	layout, err := r.resolveLayout(ctx, params.TemplateID, folder.ReportTemplateID)
 	if err != nil {
-		return nil, fmt.Errorf("db error: %w", err)
+		return nil, err
 	}
 
-	taskRows := make([]*TaskReportRow, len(tasks))
+	meta := buildMeta(folder)
+	tasks := r.taskRows(ctx, reportTaskQuery(folder.ID, params.TaskFilter))
+
+	render := func(w io.Writer) error {
+		if streaming, ok := generator.(StreamingReportGenerator); ok {
+			return streaming.Stream(w, layout, meta, tasks)
+		}
 
-	for i, task := range tasks {
-		taskRows[i] = &TaskReportRow{
-			SoftName:          task.SoftName,
-			RequestID:         task.RequestID,
-			Description:       task.Description,
-			AssigneePerson:    fmt.Sprintf("%s %s", task.LastName, task.FirstName),
-			TestEnvDateUpdate: task.TestEnvDateUpdate,
-			CheckStatus:       task.CheckStatus,
-			CheckResult:       task.CheckResult,
-			Comment:           task.Comment,
+		rows, err := CollectTaskRows(tasks)
+		if err != nil {
+			return err
+		}
+		report, err := generator.Generate(layout, meta, rows)
+		if err != nil {
+			return err
 		}
+		_, err = report.WriteTo(w)
+		return err
+	}
+
+	return &ReportStream{
+		FileName:    fmt.Sprintf("%s_%s.%s", folder.Name, meta.GeneratedAt.Format("2006-01-02_15-04-05"), format),
+		ContentType: generator.ContentType(),
+		render:      render,
+	}, nil
+}
+
+func (r *reportServiceImpl) ValidateSummaryParams(params *SummaryReportParams) error {
+	if len(params.FolderIDs) == 0 && params.From == nil && params.To == nil {
+		return fmt.Errorf("%w: folder IDs or a date range are required", domain.ErrValidation)
+	}
+	if params.From != nil && params.To != nil && params.To.Before(*params.From) {
+		return fmt.Errorf("%w: 'to' cannot be before 'from'", domain.ErrValidation)
+	}
+	if len(uniqueStrings(params.FolderIDs)) > maxSummaryFolders {
+		return fmt.Errorf("%w: no more than %d folders can be included in one report", domain.ErrValidation, maxSummaryFolders)
+	}
+	if params.TaskFilter != nil {
+		return params.TaskFilter.Validate()
+	}
+	return nil
+}
+
+func (r *reportServiceImpl) CreateSummary(ctx context.Context, params *SummaryReportParams) (*ReportData, error) {
+	if err := r.ValidateSummaryParams(params); err != nil {
+		return nil, err
+	}
+
+	folderIDs := uniqueStrings(params.FolderIDs)
+
+	filter := &store.FolderFilterQuery{
+		IDs:         folderIDs,
+		CreatedFrom: params.From,
+	}
+	if params.To != nil {
+		to := params.To.AddDate(0, 0, 1)
+		filter.CreatedTo = &to
+	}
+	if !params.Principal.Has(domain.PermReportDownload) {
+		filter.MemberID = params.Principal.UserID
+		filter.MemberRoles = domain.FolderRolesGranting(domain.PermReportDownload)
+	}
 
-		if task.CheckDate != nil {
-			taskRows[i].CheckDate = *task.CheckDate
+	folders, err := r.folderStore.FindByFilter(ctx, filter, store.WithCreator)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+	if len(folderIDs) > 0 && len(folders) != len(folderIDs) {
+		return nil, fmt.Errorf("%w: one or more of the requested folders are missing or not accessible", store.ErrFolderNotFound)
+	}
+	if len(folders) == 0 {
+		return nil, fmt.Errorf("%w: no folders match the given range", store.ErrFolderNotFound)
+	}
+	if len(folders) > maxSummaryFolders {
+		return nil, fmt.Errorf("%w: the range matches %d folders, no more than %d can be included in one report", domain.ErrValidation, len(folders), maxSummaryFolders)
+	}
+
+	layout, err := r.resolveLayout(ctx, params.TemplateID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]*ReportSection, len(folders))
+	for i, folder := range folders {
+		section, err := r.buildSection(ctx, folder, params.TaskFilter)
+		if err != nil {
+			return nil, err
 		}
+		sections[i] = section
 	}
 
-	report, err := r.reportGenerator.Generate(taskRows)
+	report, err := r.summaryGenerator.GenerateSummary(layout, sections)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ReportData{
-		FileName: fmt.Sprintf("%s_%s.xlsx", folder.Name, time.Now().Format("2006-01-02_15-04-05")),
-		Data:     report,
+		FileName:    fmt.Sprintf("summary_%s.%s", time.Now().Format("2006-01-02_15-04-05"), FormatXLSX),
+		ContentType: r.summaryGenerator.ContentType(),
+		Data:        report,
 	}, nil
 }
 
-func NewReportService(folderStore store.FolderStore, taskStore store.TaskStore, generator ReportGenerator) ReportService {
-	return &reportServiceImpl{folderStore: folderStore, taskStore: taskStore, reportGenerator: generator}
+func (r *reportServiceImpl) resolveLayout(ctx context.Context, templateID string, folderTemplateID *string) (*ReportLayout, error) {
+	locale := i18n.FromContext(ctx)
+
+	if templateID != "" {
+		template, err := r.templateStore.FindByID(ctx, templateID)
+		if err != nil {
+			if errors.Is(err, store.ErrTemplateNotFound) {
+				return nil, fmt.Errorf("%w: with ID: %s", err, templateID)
+			}
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+		return NewReportLayout(template, locale), nil
+	}
+
+	if folderTemplateID != nil {
+		template, err := r.templateStore.FindByID(ctx, *folderTemplateID)
+		if err == nil {
+			return NewReportLayout(template, locale), nil
+		}
+		if !errors.Is(err, store.ErrTemplateNotFound) {
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+	}
+
+	template, err := r.templateStore.FindDefault(ctx)
+	if err != nil {
+		if errors.Is(err, store.ErrTemplateNotFound) {
+			return DefaultReportLayout(locale), nil
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return NewReportLayout(template, locale), nil
+}
+
+func (r *reportServiceImpl) buildSection(ctx context.Context, folder *domain.Folder, taskFilter *domain.ReportFilter) (*ReportSection, error) {
+	tasks, err := CollectTaskRows(r.taskRows(ctx, reportTaskQuery(folder.ID, taskFilter)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportSection{Meta: buildMeta(folder), Tasks: tasks}, nil
+}
+
+func (r *reportServiceImpl) taskRows(ctx context.Context, query *store.TaskFilterQuery) TaskRowSource {
+	return func(yield func(task *TaskReportRow) error) error {
+		var yieldErr error
+		err := r.taskStore.IterateByFilterWithUserInfo(ctx, query, func(task *store.TasksWithUserInfo) error {
+			row := &TaskReportRow{
+				SoftName:          task.SoftName,
+				RequestID:         task.RequestID,
+				Description:       task.Description,
+				AssigneePerson:    fmt.Sprintf("%s %s", task.LastName, task.FirstName),
+				TestEnvDateUpdate: task.TestEnvDateUpdate,
+				CheckStatus:       task.CheckStatus,
+				CheckResult:       task.CheckResult,
+				Comment:           task.Comment,
+			}
+			if task.CheckDate != nil {
+				row.CheckDate = *task.CheckDate
+			}
+
+			yieldErr = yield(row)
+			return yieldErr
+		})
+		if err != nil && yieldErr == nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+		return err
+	}
+}
+
+func CollectTaskRows(tasks TaskRowSource) ([]*TaskReportRow, error) {
+	var rows []*TaskReportRow
+	err := tasks(func(task *TaskReportRow) error {
+		rows = append(rows, task)
+		return nil
+	})
+	return rows, err
+}
+
+func SliceTaskRows(rows []*TaskReportRow) TaskRowSource {
+	return func(yield func(task *TaskReportRow) error) error {
+		for _, row := range rows {
+			if err := yield(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func buildMeta(folder *domain.Folder) *ReportMeta {
+	meta := &ReportMeta{
+		FolderName:       folder.Name,
+		ReleaseVersion:   folder.ReleaseVersion,
+		CreatedAt:        folder.CreatedAt,
+		PlannedStartDate: folder.PlannedStartDate,
+		PlannedEndDate:   folder.PlannedEndDate,
+		GeneratedAt:      time.Now(),
+	}
+	if folder.Creator != nil {
+		meta.CreatorPerson = fmt.Sprintf("%s %s", folder.Creator.LastName, folder.Creator.FirstName)
+	}
+
+	return meta
+}
+
+func reportTaskQuery(folderID string, taskFilter *domain.ReportFilter) *store.TaskFilterQuery {
+	if taskFilter == nil {
+		return &store.TaskFilterQuery{FolderID: folderID, SortDesc: true}
+	}
+
+	return &store.TaskFilterQuery{
+		FolderID:        folderID,
+		CheckStatuses:   taskFilter.CheckStatuses,
+		CheckResults:    taskFilter.CheckResults,
+		AssigneeIDs:     taskFilter.AssigneeIDs,
+		TestEnvDateFrom: taskFilter.TestEnvDateFrom,
+		TestEnvDateTo:   taskFilter.TestEnvDateTo,
+		CheckDateFrom:   taskFilter.CheckDateFrom,
+		CheckDateTo:     taskFilter.CheckDateTo,
+		Sort:            taskFilter.Sort,
+		SortDesc:        taskFilter.Order == domain.SortDesc || (taskFilter.Sort == "" && taskFilter.Order == ""),
+	}
+}
+
+func (r *reportServiceImpl) NegotiateFormat(accept string) (ReportFormat, error) {
+	if strings.TrimSpace(accept) == "" {
+		return r.defaultFormat, nil
+	}
+
+	type acceptRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	// A wildcard among equal-quality ranges, as browsers and axios send, means the default.
+	for start := 0; start < len(ranges); {
+		end := start
+		for end < len(ranges) && ranges[end].quality == ranges[start].quality {
+			end++
+		}
+		group := ranges[start:end]
+
+		for _, ar := range group {
+			if ar.mediaType == "*/*" {
+				return r.defaultFormat, nil
+			}
+		}
+		for _, ar := range group {
+			if format, ok := r.formatForMediaType(ar.mediaType); ok {
+				return format, nil
+			}
+		}
+		start = end
+	}
+
+	return "", fmt.Errorf("%w: no generator for '%s'", ErrUnsupportedFormat, accept)
+}
+
+func (r *reportServiceImpl) formatForMediaType(mediaType string) (ReportFormat, bool) {
+	for _, format := range reportFormats {
+		generator, ok := r.generators[format]
+		if !ok {
+			continue
+		}
+		generatorType, _, err := mime.ParseMediaType(generator.ContentType())
+		if err == nil && generatorType == mediaType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+func (r *reportServiceImpl) SupportsFormat(format ReportFormat) bool {
+	if format == "" {
+		return true
+	}
+	_, ok := r.generators[format]
+	return ok
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
+
+func NewReportService(folderStore store.FolderStore, taskStore store.TaskStore, templateStore store.ReportTemplateStore, summaryGenerator SummaryReportGenerator, generators ...ReportGenerator) ReportService {
+	registry := make(map[ReportFormat]ReportGenerator, len(generators))
+	for _, generator := range generators {
+		registry[generator.Format()] = generator
+	}
+
+	return &reportServiceImpl{
+		folderStore:      folderStore,
+		taskStore:        taskStore,
+		templateStore:    templateStore,
+		generators:       registry,
+		summaryGenerator: summaryGenerator,
+		defaultFormat:    FormatXLSX,
+	}
 }
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+const (
+	maxReportJobAttempts  = 3
+	reportJobPollInterval = 5 * time.Second
+	reportJobSaveTimeout  = 10 * time.Second
+	reportJobLease        = 2 * time.Minute
+)
+
+type CreateFolderReportJobParams struct {
+	FolderID   string
+	Format     ReportFormat
+	TemplateID string
+	TaskFilter *domain.ReportFilter
+	Principal  *Principal
+}
+
+type ReportJobService interface {
+	CreateFolderJob(ctx context.Context, params *CreateFolderReportJobParams) (*dto.ReportJobResponse, error)
+	CreateSummaryJob(ctx context.Context, params *SummaryReportParams) (*dto.ReportJobResponse, error)
+	Get(ctx context.Context, jobID string, principal *Principal) (*dto.ReportJobResponse, error)
+	Download(ctx context.Context, jobID string, principal *Principal) (*ReportFile, error)
+	RunWorkers(ctx context.Context)
+	CleanupExpired(ctx context.Context) error
+}
+
+var ErrReportNotReady = errors.New("report is not ready")
+
+// ReportFile is a finished job's output; the caller must close Content.
+type ReportFile struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+
+type reportJobServiceImpl struct {
+	jobStore         store.ReportJobStore
+	fileStore        store.ReportFileStore
+	reportService    ReportService
+	userStore        store.UserStore
+	permissionPolicy PermissionPolicy
+	workers          int
+	resultTTL        time.Duration
+	wakeup           chan struct{}
+}
+
+func (r *reportJobServiceImpl) CreateFolderJob(ctx context.Context, params *CreateFolderReportJobParams) (*dto.ReportJobResponse, error) {
+	if !r.reportService.SupportsFormat(params.Format) {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, params.Format)
+	}
+	if params.TaskFilter != nil {
+		if err := params.TaskFilter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	job, err := domain.NewReportJob(domain.ReportJobFolder, params.Principal.UserID, &domain.ReportJobParams{
+		FolderIDs:  []string{params.FolderID},
+		Format:     string(params.Format),
+		TemplateID: params.TemplateID,
+		TaskFilter: params.TaskFilter,
+		Locale:     string(i18n.FromContext(ctx)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.enqueue(ctx, job)
+}
+
+func (r *reportJobServiceImpl) CreateSummaryJob(ctx context.Context, params *SummaryReportParams) (*dto.ReportJobResponse, error) {
+	if err := r.reportService.ValidateSummaryParams(params); err != nil {
+		return nil, err
+	}
+
+	job, err := domain.NewReportJob(domain.ReportJobSummary, params.Principal.UserID, &domain.ReportJobParams{
+		FolderIDs:  uniqueStrings(params.FolderIDs),
+		From:       params.From,
+		To:         params.To,
+		TemplateID: params.TemplateID,
+		TaskFilter: params.TaskFilter,
+		Locale:     string(i18n.FromContext(ctx)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.enqueue(ctx, job)
+}
+
+func (r *reportJobServiceImpl) enqueue(ctx context.Context, job *domain.ReportJob) (*dto.ReportJobResponse, error) {
+	if err := r.jobStore.Save(ctx, job); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	select {
+	case r.wakeup <- struct{}{}:
+	default:
+	}
+
+	return mapReportJobToResponse(job), nil
+}
+
+func (r *reportJobServiceImpl) Get(ctx context.Context, jobID string, principal *Principal) (*dto.ReportJobResponse, error) {
+	job, err := r.findOwned(ctx, jobID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReportJobToResponse(job), nil
+}
+
+func (r *reportJobServiceImpl) Download(ctx context.Context, jobID string, principal *Principal) (*ReportFile, error) {
+	job, err := r.findOwned(ctx, jobID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != domain.ReportJobDone {
+		return nil, fmt.Errorf("%w: job status is '%s'", ErrReportNotReady, job.Status)
+	}
+
+	content, err := r.fileStore.Open(ctx, job.FileKey)
+	if err != nil {
+		if errors.Is(err, store.ErrReportFileNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", store.ErrReportJobNotFound, jobID)
+		}
+		return nil, fmt.Errorf("storage error: %w", err)
+	}
+
+	return &ReportFile{
+		FileName:    job.FileName,
+		ContentType: job.ContentType,
+		Size:        job.FileSize,
+		Content:     content,
+	}, nil
+}
+
+func (r *reportJobServiceImpl) findOwned(ctx context.Context, jobID string, principal *Principal) (*domain.ReportJob, error) {
+	job, err := r.jobStore.FindByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, store.ErrReportJobNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, jobID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if job.RequestedBy != principal.UserID {
+		return nil, fmt.Errorf("%w: with ID: %s", store.ErrReportJobNotFound, jobID)
+	}
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: with ID: %s", store.ErrReportJobNotFound, jobID)
+	}
+
+	return job, nil
+}
+
+func (r *reportJobServiceImpl) RunWorkers(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *reportJobServiceImpl) work(ctx context.Context) {
+	ticker := time.NewTicker(reportJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *reportJobServiceImpl) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.jobStore.ClaimNext(ctx, time.Now().UTC(), reportJobLease)
+		if err != nil {
+			if !errors.Is(err, store.ErrReportJobNotFound) {
+				log.Printf("REPORT_JOB_ERROR: couldn't claim job: %v", err)
+			}
+			return
+		}
+
+		r.process(ctx, job)
+	}
+}
+
+func (r *reportJobServiceImpl) process(ctx context.Context, job *domain.ReportJob) {
+	stopHeartbeat := r.heartbeat(ctx, job.ID)
+	report, err := r.generate(ctx, job)
+	var size int64
+	if err == nil {
+		size, err = r.fileStore.Save(ctx, job.ID, report.Render)
+	}
+	stopHeartbeat()
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err != nil {
+		log.Printf("REPORT_JOB_ERROR: job %s failed: %v", job.ID, err)
+		job.Fail(err.Error(), r.resultTTL)
+	} else {
+		job.Complete(report.FileName, report.ContentType, job.ID, size, r.resultTTL)
+	}
+
+	saveCtx, cancel := context.WithTimeout(context.Background(), reportJobSaveTimeout)
+	defer cancel()
+
+	if err := r.jobStore.Save(saveCtx, job); err != nil {
+		log.Printf("REPORT_JOB_ERROR: couldn't save job %s: %v", job.ID, err)
+		if job.FileKey != "" {
+			r.deleteFile(saveCtx, job.FileKey)
+		}
+	}
+}
+
+func (r *reportJobServiceImpl) deleteFile(ctx context.Context, key string) {
+	if err := r.fileStore.Delete(ctx, key); err != nil {
+		log.Printf("REPORT_JOB_ERROR: couldn't delete report file %s: %v", key, err)
+	}
+}
+
+func (r *reportJobServiceImpl) heartbeat(ctx context.Context, jobID string) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(reportJobLease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.jobStore.ExtendLease(ctx, jobID, time.Now().UTC().Add(reportJobLease)); err != nil && ctx.Err() == nil {
+					log.Printf("REPORT_JOB_ERROR: couldn't extend lease of job %s: %v", jobID, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (r *reportJobServiceImpl) generate(ctx context.Context, job *domain.ReportJob) (*ReportStream, error) {
+	if job.Attempts > maxReportJobAttempts {
+		return nil, fmt.Errorf("gave up after %d attempts", maxReportJobAttempts)
+	}
+
+	params, err := job.DecodeParams()
+	if err != nil {
+		return nil, err
+	}
+	if locale, ok := i18n.Parse(params.Locale); ok {
+		ctx = i18n.WithLocale(ctx, locale)
+	}
+
+	switch job.Kind {
+	case domain.ReportJobFolder:
+		return r.reportService.Open(ctx, &CreateReportParams{
+			FolderID:   params.FolderIDs[0],
+			Format:     ReportFormat(params.Format),
+			TemplateID: params.TemplateID,
+			TaskFilter: params.TaskFilter,
+		})
+	case domain.ReportJobSummary:
+		user, err := r.userStore.FindById(ctx, job.RequestedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := r.reportService.CreateSummary(ctx, &SummaryReportParams{
+			FolderIDs:  params.FolderIDs,
+			From:       params.From,
+			To:         params.To,
+			TemplateID: params.TemplateID,
+			TaskFilter: params.TaskFilter,
+			Principal:  principalOf(user, r.permissionPolicy),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &ReportStream{
+			FileName:    report.FileName,
+			ContentType: report.ContentType,
+			render: func(w io.Writer) error {
+				_, err := report.Data.WriteTo(w)
+				return err
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown report job kind '%s'", job.Kind)
+	}
+}
+
+func (r *reportJobServiceImpl) CleanupExpired(ctx context.Context) error {
+	deleted, err := r.jobStore.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	for _, job := range deleted {
+		if job.FileKey != "" {
+			r.deleteFile(ctx, job.FileKey)
+		}
+	}
+	if len(deleted) > 0 {
+		log.Printf("REPORT_JOB: deleted %d expired jobs", len(deleted))
+	}
+
+	return nil
+}
+
+func mapReportJobToResponse(job *domain.ReportJob) *dto.ReportJobResponse {
+	response := &dto.ReportJobResponse{
+		ID:         job.ID,
+		Kind:       string(job.Kind),
+		Status:     string(job.Status),
+		FileName:   job.FileName,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+		ExpiresAt:  job.ExpiresAt,
+	}
+	if job.Status == domain.ReportJobDone {
+		response.DownloadURL = fmt.Sprintf("/api/report-jobs/%s/file", job.ID)
+	}
+
+	return response
+}
+
+func NewReportJobService(jobStore store.ReportJobStore, fileStore store.ReportFileStore, reportService ReportService, userStore store.UserStore, permissionPolicy PermissionPolicy, workers int, resultTTL time.Duration) ReportJobService {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &reportJobServiceImpl{
+		jobStore:         jobStore,
+		fileStore:        fileStore,
+		reportService:    reportService,
+		userStore:        userStore,
+		permissionPolicy: permissionPolicy,
+		workers:          workers,
+		resultTTL:        resultTTL,
+		wakeup:           make(chan struct{}, workers),
+	}
+}
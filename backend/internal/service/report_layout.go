@@ -0,0 +1,145 @@
+package service
+
+import (
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+)
+
+type ReportLayout struct {
+	Columns    []domain.ReportColumn
+	DateFormat string
+	Locale     i18n.Locale
+	dateLayout string
+}
+
+var defaultColumnWidths = map[domain.ReportField]float64{
+	domain.FieldSoftName:          25,
+	domain.FieldRequestID:         20,
+	domain.FieldDescription:       40,
+	domain.FieldAssigneePerson:    20,
+	domain.FieldTestEnvDateUpdate: 20,
+	domain.FieldCheckDate:         15,
+	domain.FieldCheckStatus:       15,
+	domain.FieldCheckResult:       15,
+	domain.FieldComment:           35,
+}
+
+func DefaultReportLayout(locale i18n.Locale) *ReportLayout {
+	columns := make([]domain.ReportColumn, len(domain.AllReportFields))
+	for i, field := range domain.AllReportFields {
+		columns[i] = domain.ReportColumn{
+			Field:  field,
+			Header: i18n.T(locale, "report.column."+string(field)),
+			Width:  defaultColumnWidths[field],
+		}
+	}
+
+	return &ReportLayout{
+		Columns:    columns,
+		DateFormat: domain.DefaultReportDateFormat,
+		Locale:     locale,
+		dateLayout: "02.01.2006",
+	}
+}
+
+func NewReportLayout(template *domain.ReportTemplate, locale i18n.Locale) *ReportLayout {
+	return &ReportLayout{
+		Columns:    template.Columns,
+		DateFormat: template.DateFormat,
+		Locale:     locale,
+		dateLayout: template.GoDateLayout(),
+	}
+}
+
+func (l *ReportLayout) T(key string, args ...any) string {
+	return i18n.T(l.Locale, key, args...)
+}
+
+func (l *ReportLayout) Status(status domain.CheckStatus) string {
+	return l.T("status." + string(status))
+}
+
+func (l *ReportLayout) Result(result domain.CheckResult) string {
+	if result == "" {
+		return l.T("result.none")
+	}
+	return l.T("result." + string(result))
+}
+
+func (l *ReportLayout) Headers() []string {
+	headers := make([]string, len(l.Columns))
+	for i, column := range l.Columns {
+		headers[i] = column.Header
+	}
+	return headers
+}
+
+func (l *ReportLayout) Widths() []float64 {
+	widths := make([]float64, len(l.Columns))
+	for i, column := range l.Columns {
+		widths[i] = column.Width
+	}
+	return widths
+}
+
+func (l *ReportLayout) ColumnIndex(field domain.ReportField) int {
+	for i, column := range l.Columns {
+		if column.Field == field {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *ReportLayout) Value(field domain.ReportField, task *TaskReportRow) interface{} {
+	switch field {
+	case domain.FieldSoftName:
+		return task.SoftName
+	case domain.FieldRequestID:
+		return task.RequestID
+	case domain.FieldDescription:
+		return task.Description
+	case domain.FieldAssigneePerson:
+		return task.AssigneePerson
+	case domain.FieldTestEnvDateUpdate:
+		return task.TestEnvDateUpdate
+	case domain.FieldCheckDate:
+		return task.CheckDate
+	case domain.FieldCheckStatus:
+		return l.Status(task.CheckStatus)
+	case domain.FieldCheckResult:
+		return l.Result(task.CheckResult)
+	case domain.FieldComment:
+		return task.Comment
+	default:
+		return ""
+	}
+}
+
+func (l *ReportLayout) Text(field domain.ReportField, task *TaskReportRow) string {
+	switch value := l.Value(field, task).(type) {
+	case time.Time:
+		return l.FormatDate(value)
+	case string:
+		return value
+	default:
+		return ""
+	}
+}
+
+func (l *ReportLayout) Texts(task *TaskReportRow) []string {
+	values := make([]string, len(l.Columns))
+	for i, column := range l.Columns {
+		values[i] = l.Text(column.Field, task)
+	}
+	return values
+}
+
+func (l *ReportLayout) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(l.dateLayout)
+}
@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
+	"github.com/pesos228/bug-tracker/internal/notification"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type CreateReportScheduleParams struct {
+	Name       string
+	Kind       string
+	FolderIDs  []string
+	Format     string
+	Cron       string
+	Timezone   string
+	Recipients []string
+	TaskFilter *domain.ReportFilter
+	Principal  *Principal
+}
+
+type UpdateReportScheduleParams struct {
+	ScheduleID string
+	Name       *string
+	Format     *string
+	Cron       *string
+	Timezone   *string
+	Recipients []string
+	TaskFilter *domain.ReportFilter
+	Enabled    *bool
+	Principal  *Principal
+}
+
+type ListScheduleRunsParams struct {
+	ScheduleID string
+	Page       int
+	PageSize   int
+	Principal  *Principal
+}
+
+type ReportScheduleService interface {
+	Create(ctx context.Context, params *CreateReportScheduleParams) (*dto.ReportScheduleResponse, error)
+	List(ctx context.Context, principal *Principal) ([]*dto.ReportScheduleResponse, error)
+	Get(ctx context.Context, scheduleID string, principal *Principal) (*dto.ReportScheduleResponse, error)
+	Update(ctx context.Context, params *UpdateReportScheduleParams) (*dto.ReportScheduleResponse, error)
+	Delete(ctx context.Context, scheduleID string, principal *Principal) error
+	ListRuns(ctx context.Context, params *ListScheduleRunsParams) (*dto.ReportScheduleRunsResponse, error)
+	RunDue(ctx context.Context) error
+}
+
+type ReportScheduleServiceDeps struct {
+	ScheduleStore    store.ReportScheduleStore
+	UserStore        store.UserStore
+	ReportService    ReportService
+	AccessService    AccessService
+	PermissionPolicy PermissionPolicy
+	TxManager        store.TxManager
+	EmailNotifier    notification.Notifier
+	// RecipientDomains may receive reports without being users with access to the folders.
+	RecipientDomains []string
+}
+
+type reportScheduleServiceImpl struct {
+	scheduleStore    store.ReportScheduleStore
+	userStore        store.UserStore
+	reportService    ReportService
+	accessService    AccessService
+	permissionPolicy PermissionPolicy
+	txManager        store.TxManager
+	emailNotifier    notification.Notifier
+	recipientDomains []string
+}
+
+func (r *reportScheduleServiceImpl) Create(ctx context.Context, params *CreateReportScheduleParams) (*dto.ReportScheduleResponse, error) {
+	folderIDs := uniqueStrings(params.FolderIDs)
+
+	kind := domain.ReportJobKind(strings.ToLower(strings.TrimSpace(params.Kind)))
+	if kind == "" {
+		kind = domain.ReportJobFolder
+		if len(folderIDs) > 1 {
+			kind = domain.ReportJobSummary
+		}
+	}
+
+	format := ReportFormat(strings.ToLower(strings.TrimSpace(params.Format)))
+	if err := r.validateFormat(kind, format); err != nil {
+		return nil, err
+	}
+
+	for _, folderID := range folderIDs {
+		if err := r.accessService.AuthorizeFolder(ctx, params.Principal, folderID, domain.PermReportDownload); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule, err := domain.NewReportSchedule(&domain.ReportScheduleParams{
+		Name:       params.Name,
+		Kind:       kind,
+		FolderIDs:  folderIDs,
+		Format:     string(format),
+		Cron:       params.Cron,
+		Timezone:   params.Timezone,
+		Recipients: params.Recipients,
+		TaskFilter: params.TaskFilter,
+		Locale:     string(i18n.FromContext(ctx)),
+		CreatedBy:  params.Principal.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.checkRecipients(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	if err := r.scheduleStore.Save(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+func (r *reportScheduleServiceImpl) List(ctx context.Context, principal *Principal) ([]*dto.ReportScheduleResponse, error) {
+	schedules, err := r.scheduleStore.FindByCreator(ctx, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	response := make([]*dto.ReportScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		response[i] = mapScheduleToResponse(schedule)
+	}
+
+	return response, nil
+}
+
+func (r *reportScheduleServiceImpl) Get(ctx context.Context, scheduleID string, principal *Principal) (*dto.ReportScheduleResponse, error) {
+	schedule, err := r.findOwned(ctx, scheduleID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+func (r *reportScheduleServiceImpl) Update(ctx context.Context, params *UpdateReportScheduleParams) (*dto.ReportScheduleResponse, error) {
+	schedule, err := r.findOwned(ctx, params.ScheduleID, params.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	var format *string
+	if params.Format != nil {
+		normalized := strings.ToLower(strings.TrimSpace(*params.Format))
+		if err := r.validateFormat(schedule.Kind, ReportFormat(normalized)); err != nil {
+			return nil, err
+		}
+		format = &normalized
+	}
+
+	err = schedule.Update(&domain.UpdateReportScheduleParams{
+		Name:       params.Name,
+		Format:     format,
+		Cron:       params.Cron,
+		Timezone:   params.Timezone,
+		Recipients: params.Recipients,
+		TaskFilter: params.TaskFilter,
+		Enabled:    params.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Recipients != nil {
+		if err := r.checkRecipients(ctx, schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.scheduleStore.Save(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapScheduleToResponse(schedule), nil
+}
+
+func (r *reportScheduleServiceImpl) Delete(ctx context.Context, scheduleID string, principal *Principal) error {
+	if _, err := r.findOwned(ctx, scheduleID, principal); err != nil {
+		return err
+	}
+
+	if err := r.scheduleStore.Delete(ctx, scheduleID); err != nil {
+		if errors.Is(err, store.ErrScheduleNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, scheduleID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reportScheduleServiceImpl) ListRuns(ctx context.Context, params *ListScheduleRunsParams) (*dto.ReportScheduleRunsResponse, error) {
+	if _, err := r.findOwned(ctx, params.ScheduleID, params.Principal); err != nil {
+		return nil, err
+	}
+
+	runs, count, err := r.scheduleStore.FindRuns(ctx, params.ScheduleID, params.Page, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	data := make([]*dto.ReportScheduleRunResponse, len(runs))
+	for i, run := range runs {
+		data[i] = &dto.ReportScheduleRunResponse{
+			ID:         run.ID,
+			Status:     string(run.Status),
+			FileName:   run.FileName,
+			Error:      run.Error,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+		}
+	}
+
+	return &dto.ReportScheduleRunsResponse{
+		Data:       data,
+		Pagination: store.CalculatePaginationResult(params.Page, params.PageSize, count),
+	}, nil
+}
+
+// RunDue only picks schedules due at the start of the pass, so each one runs at most once per pass.
+func (r *reportScheduleServiceImpl) RunDue(ctx context.Context) error {
+	passStartedAt := time.Now().UTC()
+	for ctx.Err() == nil {
+		startedAt := time.Now().UTC()
+
+		var schedule *domain.ReportSchedule
+		err := r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			due, err := r.scheduleStore.FindDueForUpdate(ctx, passStartedAt)
+			if err != nil {
+				return err
+			}
+
+			if err := due.ScheduleNext(startedAt); err != nil {
+				due.Enabled = false
+				due.LastError = err.Error()
+			}
+			schedule = due
+
+			return r.scheduleStore.Save(ctx, due)
+		})
+		if err != nil {
+			if errors.Is(err, store.ErrScheduleNotFound) {
+				return nil
+			}
+			return fmt.Errorf("db error: %w", err)
+		}
+
+		if schedule.Enabled {
+			r.run(ctx, schedule, startedAt)
+		}
+	}
+
+	return nil
+}
+
+func (r *reportScheduleServiceImpl) run(ctx context.Context, schedule *domain.ReportSchedule, startedAt time.Time) {
+	locale, ok := i18n.Parse(schedule.Locale)
+	if !ok {
+		locale = i18n.DefaultLocale
+	}
+
+	var recipients []string
+	report, err := r.generate(i18n.WithLocale(ctx, locale), schedule)
+	if err == nil {
+		recipients, err = r.allowedRecipients(ctx, schedule)
+	}
+	if err == nil {
+		err = r.emailNotifier.SendReport(recipients, &notification.ReportEmail{
+			Locale:       locale,
+			ScheduleName: schedule.Name,
+			FileName:     report.FileName,
+			ContentType:  report.ContentType,
+			Data:         report.Data.Bytes(),
+			GeneratedAt:  startedAt,
+		})
+	}
+
+	var fileName string
+	if report != nil {
+		fileName = report.FileName
+	}
+	if err != nil {
+		log.Printf("REPORT_SCHEDULE_ERROR: schedule %s failed: %v", schedule.ID, err)
+	}
+
+	run := schedule.RecordRun(startedAt, fileName, err)
+	if errors.Is(err, store.ErrUserNotFound) || errors.Is(err, ErrAccessDenied) {
+		schedule.Disable(err.Error())
+	}
+	if err := r.scheduleStore.RecordRun(ctx, schedule, run); err != nil {
+		log.Printf("REPORT_SCHEDULE_ERROR: couldn't record run of schedule %s: %v", schedule.ID, err)
+	}
+}
+
+func (r *reportScheduleServiceImpl) generate(ctx context.Context, schedule *domain.ReportSchedule) (*ReportData, error) {
+	principal, err := r.principalFor(ctx, schedule.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, folderID := range schedule.FolderIDs {
+		if err := r.accessService.AuthorizeFolder(ctx, principal, folderID, domain.PermReportDownload); err != nil {
+			return nil, err
+		}
+	}
+
+	switch schedule.Kind {
+	case domain.ReportJobFolder:
+		return r.reportService.Create(ctx, &CreateReportParams{
+			FolderID:   schedule.FolderIDs[0],
+			Format:     ReportFormat(schedule.Format),
+			TaskFilter: schedule.TaskFilter,
+		})
+	case domain.ReportJobSummary:
+		return r.reportService.CreateSummary(ctx, &SummaryReportParams{
+			FolderIDs:  schedule.FolderIDs,
+			TaskFilter: schedule.TaskFilter,
+			Principal:  principal,
+		})
+	default:
+		return nil, fmt.Errorf("unknown report kind '%s'", schedule.Kind)
+	}
+}
+
+// principalFor uses the current roles, so a schedule never outlives its creator's access.
+func (r *reportScheduleServiceImpl) principalFor(ctx context.Context, userID string) (*Principal, error) {
+	user, err := r.userStore.FindById(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, userID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return principalOf(user, r.permissionPolicy), nil
+}
+
+func (r *reportScheduleServiceImpl) checkRecipients(ctx context.Context, schedule *domain.ReportSchedule) error {
+	for _, recipient := range schedule.Recipients {
+		allowed, err := r.recipientAllowed(ctx, schedule, recipient)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("%w: recipient '%s' has no access to the scheduled folders", domain.ErrValidation, recipient)
+		}
+	}
+	return nil
+}
+
+func (r *reportScheduleServiceImpl) allowedRecipients(ctx context.Context, schedule *domain.ReportSchedule) ([]string, error) {
+	recipients := make([]string, 0, len(schedule.Recipients))
+	for _, recipient := range schedule.Recipients {
+		allowed, err := r.recipientAllowed(ctx, schedule, recipient)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil, errors.New("none of the recipients has access to the scheduled folders")
+	}
+	return recipients, nil
+}
+
+func (r *reportScheduleServiceImpl) recipientAllowed(ctx context.Context, schedule *domain.ReportSchedule, recipient string) (bool, error) {
+	address, err := mail.ParseAddress(recipient)
+	if err != nil {
+		return false, nil
+	}
+
+	if _, domainName, ok := strings.Cut(address.Address, "@"); ok {
+		for _, allowed := range r.recipientDomains {
+			if strings.EqualFold(domainName, allowed) {
+				return true, nil
+			}
+		}
+	}
+
+	user, err := r.userStore.FindByEmail(ctx, address.Address)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("db error: %w", err)
+	}
+
+	principal, err := r.principalFor(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, folderID := range schedule.FolderIDs {
+		if err := r.accessService.AuthorizeFolder(ctx, principal, folderID, domain.PermFolderRead); err != nil {
+			if errors.Is(err, ErrAccessDenied) || errors.Is(err, store.ErrFolderNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (r *reportScheduleServiceImpl) validateFormat(kind domain.ReportJobKind, format ReportFormat) error {
+	if kind == domain.ReportJobSummary && format != "" && format != FormatXLSX {
+		return fmt.Errorf("%w: summary reports are only available as '%s'", ErrUnsupportedFormat, FormatXLSX)
+	}
+	if !r.reportService.SupportsFormat(format) {
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, format)
+	}
+	return nil
+}
+
+func (r *reportScheduleServiceImpl) findOwned(ctx context.Context, scheduleID string, principal *Principal) (*domain.ReportSchedule, error) {
+	schedule, err := r.scheduleStore.FindByID(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, store.ErrScheduleNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, scheduleID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if schedule.CreatedBy != principal.UserID {
+		return nil, fmt.Errorf("%w: with ID: %s", store.ErrScheduleNotFound, scheduleID)
+	}
+
+	return schedule, nil
+}
+
+func mapScheduleToResponse(schedule *domain.ReportSchedule) *dto.ReportScheduleResponse {
+	return &dto.ReportScheduleResponse{
+		ID:         schedule.ID,
+		Name:       schedule.Name,
+		Kind:       string(schedule.Kind),
+		FolderIDs:  schedule.FolderIDs,
+		Format:     schedule.Format,
+		Cron:       schedule.Cron,
+		Timezone:   schedule.Timezone,
+		Recipients: schedule.Recipients,
+		Filter:     mapReportFilterToResponse(schedule.TaskFilter),
+		Enabled:    schedule.Enabled,
+		NextRunAt:  schedule.NextRunAt,
+		LastRunAt:  schedule.LastRunAt,
+		LastError:  schedule.LastError,
+		CreatedAt:  schedule.CreatedAt,
+	}
+}
+
+func mapReportFilterToResponse(filter *domain.ReportFilter) *dto.ReportTaskFilterRequest {
+	if filter == nil {
+		return nil
+	}
+
+	return &dto.ReportTaskFilterRequest{
+		CheckStatuses:   filter.CheckStatuses,
+		CheckResults:    filter.CheckResults,
+		AssigneeIDs:     filter.AssigneeIDs,
+		TestEnvDateFrom: filter.TestEnvDateFrom,
+		TestEnvDateTo:   filter.TestEnvDateTo,
+		CheckDateFrom:   filter.CheckDateFrom,
+		CheckDateTo:     filter.CheckDateTo,
+		Sort:            filter.Sort,
+		Order:           filter.Order,
+	}
+}
+
+func NewReportScheduleService(deps *ReportScheduleServiceDeps) ReportScheduleService {
+	return &reportScheduleServiceImpl{
+		scheduleStore:    deps.ScheduleStore,
+		userStore:        deps.UserStore,
+		reportService:    deps.ReportService,
+		accessService:    deps.AccessService,
+		permissionPolicy: deps.PermissionPolicy,
+		txManager:        deps.TxManager,
+		emailNotifier:    deps.EmailNotifier,
+		recipientDomains: deps.RecipientDomains,
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type CreateReportTemplateParams struct {
+	Name       string
+	Columns    []dto.ReportColumnDto
+	DateFormat string
+	IsDefault  bool
+}
+
+type UpdateReportTemplateParams struct {
+	TemplateID string
+	Name       *string
+	Columns    []dto.ReportColumnDto
+	DateFormat *string
+	IsDefault  *bool
+}
+
+type ReportTemplateService interface {
+	List(ctx context.Context) ([]*dto.ReportTemplateResponse, error)
+	Create(ctx context.Context, params *CreateReportTemplateParams) (*dto.ReportTemplateResponse, error)
+	Update(ctx context.Context, params *UpdateReportTemplateParams) (*dto.ReportTemplateResponse, error)
+	Delete(ctx context.Context, templateID string) error
+}
+
+type reportTemplateServiceImpl struct {
+	templateStore store.ReportTemplateStore
+}
+
+func (r *reportTemplateServiceImpl) List(ctx context.Context) ([]*dto.ReportTemplateResponse, error) {
+	templates, err := r.templateStore.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	response := make([]*dto.ReportTemplateResponse, len(templates))
+	for i, template := range templates {
+		response[i] = mapTemplateToResponse(template)
+	}
+
+	return response, nil
+}
+
+func (r *reportTemplateServiceImpl) Create(ctx context.Context, params *CreateReportTemplateParams) (*dto.ReportTemplateResponse, error) {
+	template, err := domain.NewReportTemplate(params.Name, mapColumnsToDomain(params.Columns), params.DateFormat, params.IsDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.templateStore.Save(ctx, template); err != nil {
+		if errors.Is(err, store.ErrTemplateNameTaken) {
+			return nil, fmt.Errorf("%w: '%s'", err, template.Name)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapTemplateToResponse(template), nil
+}
+
+func (r *reportTemplateServiceImpl) Update(ctx context.Context, params *UpdateReportTemplateParams) (*dto.ReportTemplateResponse, error) {
+	template, err := r.templateStore.FindByID(ctx, params.TemplateID)
+	if err != nil {
+		if errors.Is(err, store.ErrTemplateNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, params.TemplateID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	err = template.Update(&domain.UpdateReportTemplateParams{
+		Name:       params.Name,
+		Columns:    mapColumnsToDomain(params.Columns),
+		DateFormat: params.DateFormat,
+		IsDefault:  params.IsDefault,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.templateStore.Save(ctx, template); err != nil {
+		if errors.Is(err, store.ErrTemplateNameTaken) {
+			return nil, fmt.Errorf("%w: '%s'", err, template.Name)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapTemplateToResponse(template), nil
+}
+
+func (r *reportTemplateServiceImpl) Delete(ctx context.Context, templateID string) error {
+	if err := r.templateStore.Delete(ctx, templateID); err != nil {
+		if errors.Is(err, store.ErrTemplateNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, templateID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return nil
+}
+
+func mapColumnsToDomain(columns []dto.ReportColumnDto) []domain.ReportColumn {
+	if columns == nil {
+		return nil
+	}
+
+	result := make([]domain.ReportColumn, len(columns))
+	for i, column := range columns {
+		result[i] = domain.ReportColumn{
+			Field:  domain.ReportField(column.Field),
+			Header: column.Header,
+			Width:  column.Width,
+		}
+	}
+	return result
+}
+
+func mapTemplateToResponse(template *domain.ReportTemplate) *dto.ReportTemplateResponse {
+	columns := make([]dto.ReportColumnDto, len(template.Columns))
+	for i, column := range template.Columns {
+		columns[i] = dto.ReportColumnDto{
+			Field:  string(column.Field),
+			Header: column.Header,
+			Width:  column.Width,
+		}
+	}
+
+	return &dto.ReportTemplateResponse{
+		ID:         template.ID,
+		Name:       template.Name,
+		Columns:    columns,
+		DateFormat: template.DateFormat,
+		IsDefault:  template.IsDefault,
+		CreatedAt:  template.CreatedAt,
+		UpdatedAt:  template.UpdatedAt,
+	}
+}
+
+func NewReportTemplateService(templateStore store.ReportTemplateStore) ReportTemplateService {
+	return &reportTemplateServiceImpl{templateStore: templateStore}
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type BurndownParams struct {
+	FolderID string
+	From     *time.Time
+	To       *time.Time
+}
+
+type TeamWorkloadParams struct {
+	FolderID string
+	From     *time.Time
+	To       *time.Time
+}
+
+type StatsService interface {
+	FolderStats(ctx context.Context, folderID string) (*dto.FolderStatsResponse, error)
+	Burndown(ctx context.Context, params *BurndownParams) (*dto.BurndownResponse, error)
+	CaptureSnapshots(ctx context.Context) error
+	TeamWorkload(ctx context.Context, params *TeamWorkloadParams) (*dto.TeamWorkloadResponse, error)
+}
+
+type statsServiceImpl struct {
+	folderStore   store.FolderStore
+	taskStore     store.TaskStore
+	snapshotStore store.SnapshotStore
+}
+
+func (s *statsServiceImpl) Burndown(ctx context.Context, params *BurndownParams) (*dto.BurndownResponse, error) {
+	folder, err := s.folderStore.FindByID(ctx, params.FolderID)
+	if err != nil {
+		if errors.Is(err, store.ErrFolderNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, params.FolderID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	from := truncateToDay(folder.CreatedAt)
+	if params.From != nil {
+		from = truncateToDay(*params.From)
+	}
+	to := truncateToDay(time.Now())
+	if params.To != nil {
+		to = truncateToDay(*params.To)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' cannot be before 'from'", domain.ErrValidation)
+	}
+
+	snapshots, err := s.snapshotStore.FindByFolderID(ctx, folder.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	points := make([]*dto.BurndownPoint, len(snapshots))
+	for i, snapshot := range snapshots {
+		points[i] = &dto.BurndownPoint{
+			Date:                  snapshot.Date,
+			NotCheckedCount:       snapshot.NotCheckedCount,
+			CheckedCount:          snapshot.CheckedCount,
+			PartiallyCheckedCount: snapshot.PartiallyCheckedCount,
+			FailedCount:           snapshot.FailedCount,
+			TotalCount:            snapshot.TotalCount(),
+		}
+	}
+
+	return &dto.BurndownResponse{
+		From:   from,
+		To:     to,
+		Points: points,
+	}, nil
+}
+
+func (s *statsServiceImpl) TeamWorkload(ctx context.Context, params *TeamWorkloadParams) (*dto.TeamWorkloadResponse, error) {
+	if params.FolderID != "" {
+		ok, err := s.folderStore.IsExists(ctx, params.FolderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check folder existence: %w", err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: with id %s", store.ErrFolderNotFound, params.FolderID)
+		}
+	}
+
+	to := truncateToDay(time.Now())
+	if params.To != nil {
+		to = truncateToDay(*params.To)
+	}
+	from := to.AddDate(0, 0, -30)
+	if params.From != nil {
+		from = truncateToDay(*params.From)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' cannot be before 'from'", domain.ErrValidation)
+	}
+
+	workload, err := s.taskStore.GetTeamWorkload(ctx, &store.TeamWorkloadQuery{
+		FolderID: params.FolderID,
+		From:     from,
+		To:       to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	userIDs := make([]string, len(workload))
+	for i, tester := range workload {
+		userIDs[i] = tester.UserID
+	}
+
+	taskCounts, err := s.taskStore.GetTaskCountsForUsers(ctx, &store.TaskCountsQuery{
+		UserIDs:            userIDs,
+		FolderID:           params.FolderID,
+		InProgressStatuses: inProgressCheckStatuses,
+		CompletedStatuses:  completedCheckStatuses,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	openCounts := make(map[string]int64, len(taskCounts))
+	for _, counts := range taskCounts {
+		openCounts[counts.UserID] = int64(counts.InProgressTasksCount)
+	}
+
+	data := make([]*dto.TesterWorkload, len(workload))
+	for i, tester := range workload {
+		data[i] = &dto.TesterWorkload{
+			UserID:        tester.UserID,
+			FullName:      fmt.Sprintf("%s %s", tester.LastName, tester.FirstName),
+			AssignedCount: tester.AssignedCount,
+			CheckedCount:  tester.CheckedCount,
+			FailedCount:   tester.FailedCount,
+			WarningCount:  tester.WarningCount,
+			OpenCount:     openCounts[tester.UserID],
+		}
+		if tester.MedianCheckSeconds != nil {
+			hours := round2(*tester.MedianCheckSeconds / 3600)
+			data[i].MedianTimeToCheckHours = &hours
+		}
+	}
+
+	return &dto.TeamWorkloadResponse{
+		From:     from,
+		To:       to,
+		FolderID: params.FolderID,
+		Data:     data,
+	}, nil
+}
+
+func (s *statsServiceImpl) CaptureSnapshots(ctx context.Context) error {
+	counts, err := s.taskStore.GetStatusCountsByFolder(ctx)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	countsByFolder := make(map[string]map[domain.CheckStatus]int64)
+	for _, count := range counts {
+		if countsByFolder[count.FolderID] == nil {
+			countsByFolder[count.FolderID] = make(map[domain.CheckStatus]int64)
+		}
+		countsByFolder[count.FolderID][count.CheckStatus] = count.Count
+	}
+
+	folders, err := s.folderStore.FindByFilter(ctx, &store.FolderFilterQuery{})
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	latest, err := s.snapshotStore.FindLatest(ctx)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+	latestByFolder := make(map[string]*domain.FolderSnapshot, len(latest))
+	for _, snapshot := range latest {
+		latestByFolder[snapshot.FolderID] = snapshot
+	}
+
+	today := truncateToDay(time.Now())
+	snapshots := make([]*domain.FolderSnapshot, 0, len(folders))
+	for _, folder := range folders {
+		// Days missed while the job was not running repeat the last known counts.
+		if last := latestByFolder[folder.ID]; last != nil {
+			for day := truncateToDay(last.Date).AddDate(0, 0, 1); day.Before(today); day = day.AddDate(0, 0, 1) {
+				snapshots = append(snapshots, domain.NewFolderSnapshot(folder.ID, day, last.Counts()))
+			}
+		}
+		snapshots = append(snapshots, domain.NewFolderSnapshot(folder.ID, today, countsByFolder[folder.ID]))
+	}
+
+	if err := s.snapshotStore.Upsert(ctx, snapshots); err != nil {
+		return fmt.Errorf("failed to save snapshots: %w", err)
+	}
+
+	return nil
+}
+
+func (s *statsServiceImpl) FolderStats(ctx context.Context, folderID string) (*dto.FolderStatsResponse, error) {
+	ok, err := s.folderStore.IsExists(ctx, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check folder existence: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: with id %s", store.ErrFolderNotFound, folderID)
+	}
+
+	stats, err := s.taskStore.GetFolderStats(ctx, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	response := &dto.FolderStatsResponse{
+		StatusCounts: map[string]int64{
+			string(domain.NotChecked):       0,
+			string(domain.Checked):          0,
+			string(domain.PartiallyChecked): 0,
+			string(domain.Failed):           0,
+		},
+		ResultCounts: map[string]int64{
+			string(domain.Success): 0,
+			string(domain.Failure): 0,
+			string(domain.Warning): 0,
+		},
+		Assignees: make([]*dto.AssigneeStats, len(stats.Assignees)),
+	}
+
+	for _, status := range stats.StatusCounts {
+		response.StatusCounts[status.Value] = status.Count
+		response.TotalCount += status.Count
+	}
+	for _, result := range stats.ResultCounts {
+		response.ResultCounts[result.Value] = result.Count
+	}
+
+	response.CompletionPercent = completionPercent(response.TotalCount, response.StatusCounts[string(domain.NotChecked)])
+
+	for i, assignee := range stats.Assignees {
+		response.Assignees[i] = &dto.AssigneeStats{
+			UserID:            assignee.UserID,
+			FullName:          fmt.Sprintf("%s %s", assignee.LastName, assignee.FirstName),
+			TotalCount:        assignee.TotalCount,
+			NotCheckedCount:   assignee.NotCheckedCount,
+			FailedCount:       assignee.FailedCount,
+			CompletionPercent: completionPercent(assignee.TotalCount, assignee.NotCheckedCount),
+		}
+	}
+
+	if stats.OldestUnchecked != nil {
+		response.OldestUncheckedTask = &dto.OldestUncheckedTask{
+			ID:        stats.OldestUnchecked.ID,
+			SoftName:  stats.OldestUnchecked.SoftName,
+			RequestID: stats.OldestUnchecked.RequestID,
+			CreatedAt: stats.OldestUnchecked.CreatedAt,
+		}
+	}
+
+	if stats.AvgCheckSeconds != nil {
+		hours := round2(*stats.AvgCheckSeconds / 3600)
+		response.AvgTimeToCheckHours = &hours
+	}
+
+	return response, nil
+}
+
+func completionPercent(total, notChecked int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round2(float64(total-notChecked) / float64(total) * 100)
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func NewStatsService(folderStore store.FolderStore, taskStore store.TaskStore, snapshotStore store.SnapshotStore) StatsService {
+	return &statsServiceImpl{folderStore: folderStore, taskStore: taskStore, snapshotStore: snapshotStore}
+}
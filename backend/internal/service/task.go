@@ -4,12 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"slices"
 	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/handler/dto"
-	"github.com/pesos228/bug-tracker/internal/notification"
 	"github.com/pesos228/bug-tracker/internal/store"
 )
 
@@ -73,7 +72,7 @@ type TaskService interface {
 	Save(ctx context.Context, params *CreateTaskParams) error
 	SearchByFolderID(ctx context.Context, params *SearchTasksByFolderIDParams) (*dto.TaskPreviewResponse, error)
 	SearchByUserID(ctx context.Context, params *SearchTasksByUserIDParams) (*dto.TaskPreviewResponse, error)
-	DeleteByID(ctx context.Context, taskID string) error
+	DeleteByID(ctx context.Context, taskID, currentUserID string) error
 	UpdateByAdmin(ctx context.Context, params *UpdateTaskParams) error
 	UpdateByUser(ctx context.Context, params *UpdateTaskParams) error
 	GetDetails(ctx context.Context, taskID, userID string) (*TaskDetails, error)
@@ -82,10 +81,14 @@ type TaskService interface {
 var ErrNotAssignee = errors.New("user is not the assignee")
 
 type taskServiceImpl struct {
-	taskStore     store.TaskStore
-	userStore     store.UserStore
-	folderStore   store.FolderStore
-	emailNotifier notification.Notifier
+	taskStore           store.TaskStore
+	userStore           store.UserStore
+	folderStore         store.FolderStore
+	mentionStore        store.MentionStore
+	notificationService NotificationService
+	webhookService      WebhookService
+	realtimeService     RealtimeService
+	txManager           store.TxManager
 }
 
 func (t *taskServiceImpl) SearchByUserID(ctx context.Context, params *SearchTasksByUserIDParams) (*dto.TaskPreviewResponse, error) {
@@ -161,14 +164,39 @@ func (t *taskServiceImpl) UpdateByUser(ctx context.Context, params *UpdateTaskPa
 		CheckDate:   &now,
 	}
 
+	previous := *task
 	if err := task.Update(domainParams); err != nil {
 		return err
 	}
 
-	if err := t.taskStore.Save(ctx, task); err != nil {
+	err = t.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.taskStore.Save(ctx, task); err != nil {
+			return err
+		}
+		if err := t.publishTaskUpdate(ctx, &previous, task); err != nil {
+			return err
+		}
+		if err := t.recordMentions(ctx, &previous, task, params.CurrentUserID); err != nil {
+			return err
+		}
+		if task.CreatorID == params.CurrentUserID {
+			return nil
+		}
+		if task.CheckResult == previous.CheckResult && task.CheckStatus == previous.CheckStatus {
+			return nil
+		}
+		return publishNotification(ctx, t.notificationService, domain.EventResultSubmitted, task.CreatorID, &domain.NotificationPayload{
+			TaskIDs:  []string{task.ID},
+			SoftName: task.SoftName,
+		})
+	})
+	if err != nil {
 		return fmt.Errorf("error while updating task: %w", err)
 	}
 
+	if changedFields := taskChanges(&previous, task); len(changedFields) > 0 {
+		t.realtimeService.PublishTaskEvent(ctx, domain.WebhookTaskUpdated, task, &previous, changedFields)
+	}
 	return nil
 }
 
@@ -185,9 +213,6 @@ func (t *taskServiceImpl) UpdateByAdmin(ctx context.Context, params *UpdateTaskP
 		if err := t.isUserExists(ctx, *params.AssigneeID); err != nil {
 			return err
 		}
-		if *params.AssigneeID != params.CurrentUserID {
-			go t.notifyAboutTask(context.Background(), *params.AssigneeID, params.TaskID)
-		}
 	}
 
 	if params.FolderID != nil {
@@ -209,24 +234,216 @@ func (t *taskServiceImpl) UpdateByAdmin(ctx context.Context, params *UpdateTaskP
 		Comment:           params.Comment,
 	}
 
+	previous := *task
 	if err := task.Update(domainParams); err != nil {
 		return err
 	}
 
-	if err := t.taskStore.Save(ctx, task); err != nil {
+	err = t.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.taskStore.Save(ctx, task); err != nil {
+			return err
+		}
+		if err := t.publishTaskUpdate(ctx, &previous, task); err != nil {
+			return err
+		}
+		if err := t.recordMentions(ctx, &previous, task, params.CurrentUserID); err != nil {
+			return err
+		}
+		return t.notifyAboutAdminUpdate(ctx, &previous, task, params.CurrentUserID)
+	})
+	if err != nil {
 		return fmt.Errorf("error while updating task: %w", err)
 	}
 
+	if changedFields := taskChanges(&previous, task); len(changedFields) > 0 {
+		t.realtimeService.PublishTaskEvent(ctx, domain.WebhookTaskUpdated, task, &previous, changedFields)
+	}
 	return nil
 }
 
-func (t *taskServiceImpl) DeleteByID(ctx context.Context, taskID string) error {
-	if err := t.taskStore.DeleteByID(ctx, taskID); err != nil {
+func (t *taskServiceImpl) publishTaskUpdate(ctx context.Context, previous, task *domain.Task) error {
+	changedFields := taskChanges(previous, task)
+	if len(changedFields) == 0 {
+		return nil
+	}
+	folderIDs := []string{task.FolderID}
+	if previous.FolderID != task.FolderID {
+		folderIDs = append(folderIDs, previous.FolderID)
+	}
+	return t.webhookService.Publish(ctx, domain.WebhookTaskUpdated, folderIDs, webhookTaskData(task, changedFields))
+}
+
+func taskChanges(previous, task *domain.Task) []string {
+	changedFields := task.ChangedFields(previous)
+	if task.AssigneeID != previous.AssigneeID {
+		changedFields = append(changedFields, "assigneeId")
+	}
+	return changedFields
+}
+
+func (t *taskServiceImpl) notifyAboutAdminUpdate(ctx context.Context, previous, task *domain.Task, currentUserID string) error {
+	if task.AssigneeID != previous.AssigneeID {
+		payload := &domain.NotificationPayload{
+			TaskIDs:            []string{task.ID},
+			SoftName:           task.SoftName,
+			PreviousAssigneeID: previous.AssigneeID,
+		}
+		for _, recipientID := range []string{previous.AssigneeID, task.AssigneeID} {
+			if recipientID == currentUserID {
+				continue
+			}
+			if err := publishNotification(ctx, t.notificationService, domain.EventTaskReassigned, recipientID, payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	changedFields := task.ChangedFields(previous)
+	if len(changedFields) == 0 || task.AssigneeID == currentUserID {
+		return nil
+	}
+	return publishNotification(ctx, t.notificationService, domain.EventTaskUpdated, task.AssigneeID, &domain.NotificationPayload{
+		TaskIDs:       []string{task.ID},
+		SoftName:      task.SoftName,
+		ChangedFields: changedFields,
+	})
+}
+
+// recordMentions grants newly mentioned users read access and notifies them; unknown or ambiguous names stay text.
+func (t *taskServiceImpl) recordMentions(ctx context.Context, previous, task *domain.Task, actorID string) error {
+	if previous.Description == task.Description && previous.Comment == task.Comment {
+		return nil
+	}
+	if err := t.revokeMentions(ctx, task); err != nil {
+		return err
+	}
+
+	texts := []struct {
+		source   domain.MentionSource
+		previous string
+		current  string
+	}{
+		{domain.MentionInDescription, previous.Description, task.Description},
+		{domain.MentionInComment, previous.Comment, task.Comment},
+	}
+
+	var mentions []*domain.TaskMention
+	for _, text := range texts {
+		for _, name := range domain.NewMentions(text.previous, text.current) {
+			user, err := t.resolveMention(ctx, name)
+			if err != nil {
+				return err
+			}
+			if user == nil || user.ID == actorID {
+				continue
+			}
+
+			mention, err := domain.NewTaskMention(task.ID, user.ID, actorID, text.source)
+			if err != nil {
+				return err
+			}
+			mentions = append(mentions, mention)
+		}
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	if err := t.mentionStore.SaveAll(ctx, mentions); err != nil {
+		return err
+	}
+
+	mentioned, err := t.mentionStore.CountUsers(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if mentioned > domain.MaxMentionedUsers {
+		return fmt.Errorf("%w: a task can mention at most %d users", domain.ErrValidation, domain.MaxMentionedUsers)
+	}
+
+	actor, err := t.userStore.FindById(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	for _, mention := range mentions {
+		err := publishNotification(ctx, t.notificationService, domain.EventTaskMentioned, mention.UserID, &domain.NotificationPayload{
+			TaskIDs:       []string{task.ID},
+			SoftName:      task.SoftName,
+			ActorName:     actor.FullName(),
+			MentionSource: string(mention.Source),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *taskServiceImpl) revokeMentions(ctx context.Context, task *domain.Task) error {
+	names := append(domain.ParseMentions(task.Description), domain.ParseMentions(task.Comment)...)
+
+	var keep []string
+	for _, name := range slices.Compact(slices.Sorted(slices.Values(names))) {
+		user, err := t.resolveMention(ctx, name)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			keep = append(keep, user.ID)
+		}
+	}
+	return t.mentionStore.DeleteExcept(ctx, task.ID, keep)
+}
+
+// resolveMention returns nil when the name matches nobody or is ambiguous.
+func (t *taskServiceImpl) resolveMention(ctx context.Context, name string) (*domain.User, error) {
+	users, err := t.userStore.FindByFullName(ctx, name)
+	if err != nil || len(users) != 1 {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+func (t *taskServiceImpl) DeleteByID(ctx context.Context, taskID, currentUserID string) error {
+	task, err := t.taskStore.FindById(ctx, taskID)
+	if err != nil {
 		if !errors.Is(err, store.ErrTaskNotFound) {
 			return fmt.Errorf("db error: %w", err)
 		}
 		return err
 	}
+
+	folder, err := t.folderStore.FindByID(ctx, task.FolderID)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	err = t.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.taskStore.DeleteByID(ctx, taskID); err != nil {
+			return err
+		}
+		if err := t.webhookService.Publish(ctx, domain.WebhookTaskDeleted, []string{task.FolderID}, webhookTaskData(task, nil)); err != nil {
+			return err
+		}
+		if task.AssigneeID == currentUserID {
+			return nil
+		}
+		return publishNotification(ctx, t.notificationService, domain.EventTaskDeleted, task.AssigneeID, &domain.NotificationPayload{
+			FolderID:   folder.ID,
+			FolderName: folder.Name,
+			SoftName:   task.SoftName,
+			RequestID:  task.RequestID,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, store.ErrTaskNotFound) {
+			return fmt.Errorf("db error: %w", err)
+		}
+		return err
+	}
+
+	t.realtimeService.PublishTaskEvent(ctx, domain.WebhookTaskDeleted, task, nil, nil)
 	return nil
 }
 
@@ -279,12 +496,29 @@ func (t *taskServiceImpl) Save(ctx context.Context, params *CreateTaskParams) er
 		return err
 	}
 
-	if err := t.taskStore.Save(ctx, newTask); err != nil {
+	err = t.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.taskStore.Save(ctx, newTask); err != nil {
+			return err
+		}
+		if err := t.webhookService.Publish(ctx, domain.WebhookTaskCreated, []string{newTask.FolderID}, webhookTaskData(newTask, nil)); err != nil {
+			return err
+		}
+		if err := t.recordMentions(ctx, &domain.Task{}, newTask, params.CreatorID); err != nil {
+			return err
+		}
+		if newTask.AssigneeID == params.CreatorID {
+			return nil
+		}
+		return publishNotification(ctx, t.notificationService, domain.EventTaskAssigned, newTask.AssigneeID, &domain.NotificationPayload{
+			TaskIDs:  []string{newTask.ID},
+			SoftName: newTask.SoftName,
+		})
+	})
+	if err != nil {
 		return fmt.Errorf("db error while saving: %s", err.Error())
 	}
 
-	go t.notifyAboutTask(context.Background(), params.AssigneeID, newTask.ID)
-
+	t.realtimeService.PublishTaskEvent(ctx, domain.WebhookTaskCreated, newTask, nil, nil)
 	return nil
 }
 
@@ -325,21 +559,15 @@ func mapTaskToTaskPreview(tasks []*domain.Task) []*dto.TaskPreview {
 	return data
 }
 
-func (t *taskServiceImpl) notifyAboutTask(ctx context.Context, userID, taskID string) {
-	user, err := t.userStore.FindById(ctx, userID)
-	if err != nil {
-		log.Printf("NOTIFY_TASK_ERROR: failed to find user in db: %v", err)
-		return
+func NewTaskService(taskStore store.TaskStore, userStore store.UserStore, folderStore store.FolderStore, mentionStore store.MentionStore, notificationService NotificationService, webhookService WebhookService, realtimeService RealtimeService, txManager store.TxManager) TaskService {
+	return &taskServiceImpl{
+		taskStore:           taskStore,
+		userStore:           userStore,
+		folderStore:         folderStore,
+		mentionStore:        mentionStore,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		realtimeService:     realtimeService,
+		txManager:           txManager,
 	}
-	task, err := t.taskStore.FindById(ctx, taskID)
-	if err != nil {
-		log.Printf("NOTIFY_TASK_ERROR: failed to find task in db: %v", err)
-		return
-	}
-
-	t.emailNotifier.NotifyAboutNewTask(user, task)
-}
-
-func NewTaskService(taskStore store.TaskStore, userStore store.UserStore, folderStore store.FolderStore, emailNotifier notification.Notifier) TaskService {
-	return &taskServiceImpl{taskStore: taskStore, userStore: userStore, folderStore: folderStore, emailNotifier: emailNotifier}
 }
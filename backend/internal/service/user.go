@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/i18n"
 	"github.com/pesos228/bug-tracker/internal/store"
 )
 
@@ -17,14 +20,34 @@ type SearchUsersParams struct {
 	FullName string
 }
 
+type UpdateProfileParams struct {
+	UserID      string
+	Locale      *string
+	ChatHandles map[string]string
+}
+
 type UserService interface {
 	Search(ctx context.Context, params *SearchUsersParams) (*dto.UserListResponse, error)
 	GetStats(ctx context.Context, userID string) (*dto.UserStatsResponse, error)
+	UpdateProfile(ctx context.Context, params *UpdateProfileParams) (*dto.ProfileResponse, error)
+	Memberships(ctx context.Context, userID string) ([]*dto.FolderMembershipResponse, error)
+	PreferredLocale(ctx context.Context, userID string) (string, error)
+}
+
+const preferredLocaleTTL = time.Minute
+
+type cachedLocale struct {
+	locale    string
+	expiresAt time.Time
 }
 
 type userServiceImpl struct {
-	userStore store.UserStore
-	taskStore store.TaskStore
+	userStore   store.UserStore
+	taskStore   store.TaskStore
+	memberStore store.FolderMemberStore
+
+	localesMu sync.Mutex
+	locales   map[string]cachedLocale
 }
 
 var (
@@ -48,7 +71,11 @@ func (u *userServiceImpl) GetStats(ctx context.Context, userID string) (*dto.Use
 		return nil, fmt.Errorf("db error: %w", err)
 	}
 
-	taskCounts, err := u.taskStore.GetTaskCountsForUsers(ctx, []string{userID}, inProgressCheckStatuses, completedCheckStatuses)
+	taskCounts, err := u.taskStore.GetTaskCountsForUsers(ctx, &store.TaskCountsQuery{
+		UserIDs:            []string{userID},
+		InProgressStatuses: inProgressCheckStatuses,
+		CompletedStatuses:  completedCheckStatuses,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error while getting task counts: %w", err)
 	}
@@ -66,6 +93,57 @@ func (u *userServiceImpl) GetStats(ctx context.Context, userID string) (*dto.Use
 	}, nil
 }
 
+func (u *userServiceImpl) UpdateProfile(ctx context.Context, params *UpdateProfileParams) (*dto.ProfileResponse, error) {
+	user, err := u.userStore.FindById(ctx, params.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, params.UserID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if params.Locale != nil {
+		user.PreferredLocale = ""
+		if strings.TrimSpace(*params.Locale) != "" {
+			locale, ok := i18n.Parse(*params.Locale)
+			if !ok {
+				return nil, fmt.Errorf("%w: unsupported locale: %s", domain.ErrValidation, *params.Locale)
+			}
+			user.PreferredLocale = string(locale)
+		}
+	}
+
+	for channel, handle := range params.ChatHandles {
+		if err := user.SetChatHandle(domain.ChatChannel(channel), handle); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.userStore.SaveProfile(ctx, user); err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, params.UserID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+	u.cacheLocale(user.ID, user.PreferredLocale)
+
+	locale, ok := i18n.Parse(user.EffectiveLocale())
+	if !ok {
+		locale = i18n.FromContext(ctx)
+	}
+
+	chatHandles := make(map[string]string, len(user.ChatHandles))
+	for channel, handle := range user.ChatHandles {
+		chatHandles[string(channel)] = handle
+	}
+
+	return &dto.ProfileResponse{
+		Locale:          string(locale),
+		PreferredLocale: user.PreferredLocale,
+		ChatHandles:     chatHandles,
+	}, nil
+}
+
 func (u *userServiceImpl) Search(ctx context.Context, params *SearchUsersParams) (*dto.UserListResponse, error) {
 	users, count, err := u.userStore.Search(ctx, &store.SearchUsersQuery{
 		Page:     params.Page,
@@ -89,7 +167,11 @@ func (u *userServiceImpl) Search(ctx context.Context, params *SearchUsersParams)
 		userIDs[i] = user.ID
 	}
 
-	taskCounts, err := u.taskStore.GetTaskCountsForUsers(ctx, userIDs, inProgressCheckStatuses, completedCheckStatuses)
+	taskCounts, err := u.taskStore.GetTaskCountsForUsers(ctx, &store.TaskCountsQuery{
+		UserIDs:            userIDs,
+		InProgressStatuses: inProgressCheckStatuses,
+		CompletedStatuses:  completedCheckStatuses,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error while getting task counts: %w", err)
 	}
@@ -127,6 +209,69 @@ func (u *userServiceImpl) Search(ctx context.Context, params *SearchUsersParams)
 	}, nil
 }
 
-func NewUserService(userStore store.UserStore, taskStore store.TaskStore) UserService {
-	return &userServiceImpl{userStore: userStore, taskStore: taskStore}
+func (u *userServiceImpl) Memberships(ctx context.Context, userID string) ([]*dto.FolderMembershipResponse, error) {
+	members, err := u.memberStore.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	response := make([]*dto.FolderMembershipResponse, len(members))
+	for i, member := range members {
+		permissions := member.Role.Permissions()
+		names := make([]string, len(permissions))
+		for j, permission := range permissions {
+			names[j] = string(permission)
+		}
+
+		response[i] = &dto.FolderMembershipResponse{
+			FolderID:    member.FolderID,
+			Role:        string(member.Role),
+			Permissions: names,
+		}
+	}
+
+	return response, nil
+}
+
+// PreferredLocale is resolved on every authenticated request, so it is served from a short-lived cache.
+func (u *userServiceImpl) PreferredLocale(ctx context.Context, userID string) (string, error) {
+	u.localesMu.Lock()
+	cached, ok := u.locales[userID]
+	u.localesMu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.locale, nil
+	}
+
+	user, err := u.userStore.FindById(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrUserNotFound) {
+			return "", fmt.Errorf("%w: with ID: %s", err, userID)
+		}
+		return "", fmt.Errorf("db error: %w", err)
+	}
+
+	u.cacheLocale(userID, user.PreferredLocale)
+	return user.PreferredLocale, nil
+}
+
+func (u *userServiceImpl) cacheLocale(userID, locale string) {
+	u.localesMu.Lock()
+	defer u.localesMu.Unlock()
+
+	now := time.Now()
+	for id, cached := range u.locales {
+		if now.After(cached.expiresAt) {
+			delete(u.locales, id)
+		}
+	}
+	u.locales[userID] = cachedLocale{locale: locale, expiresAt: now.Add(preferredLocaleTTL)}
+}
+
+func NewUserService(userStore store.UserStore, taskStore store.TaskStore, memberStore store.FolderMemberStore) UserService {
+	return &userServiceImpl{
+		userStore:   userStore,
+		taskStore:   taskStore,
+		memberStore: memberStore,
+		locales:     make(map[string]cachedLocale),
+	}
 }
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/handler/dto"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"github.com/pesos228/bug-tracker/internal/webhook"
+)
+
+const (
+	webhookBatchSize = 20
+	webhookLease     = 5 * time.Minute
+)
+
+type CreateWebhookParams struct {
+	Name      string
+	URL       string
+	Secret    string
+	Events    []string
+	FolderID  *string
+	CreatedBy string
+}
+
+type UpdateWebhookParams struct {
+	WebhookID string
+	Name      *string
+	URL       *string
+	Secret    *string
+	Events    []string
+	FolderID  *string
+	Enabled   *bool
+}
+
+type WebhookService interface {
+	Create(ctx context.Context, params *CreateWebhookParams) (*dto.WebhookResponse, error)
+	Get(ctx context.Context, webhookID string) (*dto.WebhookResponse, error)
+	List(ctx context.Context, page, pageSize int) (*dto.WebhookListResponse, error)
+	Update(ctx context.Context, params *UpdateWebhookParams) (*dto.WebhookResponse, error)
+	Delete(ctx context.Context, webhookID string) error
+	ListDeliveries(ctx context.Context, webhookID string, page, pageSize int) (*dto.WebhookDeliveryListResponse, error)
+	SendTest(ctx context.Context, webhookID string) (*dto.WebhookDeliveryResponse, error)
+	Publish(ctx context.Context, event domain.WebhookEvent, folderIDs []string, data ...any) error
+	ProcessDue(ctx context.Context) error
+}
+
+type WebhookServiceDeps struct {
+	WebhookStore  store.WebhookStore
+	DeliveryStore store.WebhookDeliveryStore
+	FolderStore   store.FolderStore
+	Sender        webhook.Sender
+	MaxAttempts   int
+}
+
+type webhookServiceImpl struct {
+	webhookStore  store.WebhookStore
+	deliveryStore store.WebhookDeliveryStore
+	folderStore   store.FolderStore
+	sender        webhook.Sender
+	maxAttempts   int
+}
+
+func (s *webhookServiceImpl) Create(ctx context.Context, params *CreateWebhookParams) (*dto.WebhookResponse, error) {
+	if err := s.checkFolder(ctx, params.FolderID); err != nil {
+		return nil, err
+	}
+
+	secret := params.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	hook, err := domain.NewWebhook(&domain.WebhookParams{
+		Name:      params.Name,
+		URL:       params.URL,
+		Secret:    secret,
+		Events:    toWebhookEvents(params.Events),
+		FolderID:  params.FolderID,
+		CreatedBy: params.CreatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookStore.Save(ctx, hook); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	// The secret is only shown once, so a generated one can be copied to the receiver.
+	response := mapWebhookToResponse(hook)
+	response.Secret = hook.Secret
+	return response, nil
+}
+
+func (s *webhookServiceImpl) Get(ctx context.Context, webhookID string) (*dto.WebhookResponse, error) {
+	hook, err := s.find(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return mapWebhookToResponse(hook), nil
+}
+
+func (s *webhookServiceImpl) List(ctx context.Context, page, pageSize int) (*dto.WebhookListResponse, error) {
+	hooks, count, err := s.webhookStore.FindAll(ctx, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	data := make([]*dto.WebhookResponse, len(hooks))
+	for i, hook := range hooks {
+		data[i] = mapWebhookToResponse(hook)
+	}
+
+	return &dto.WebhookListResponse{
+		Data:       data,
+		Pagination: store.CalculatePaginationResult(page, pageSize, count),
+	}, nil
+}
+
+func (s *webhookServiceImpl) Update(ctx context.Context, params *UpdateWebhookParams) (*dto.WebhookResponse, error) {
+	hook, err := s.find(ctx, params.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.FolderID != nil && *params.FolderID != "" {
+		if err := s.checkFolder(ctx, params.FolderID); err != nil {
+			return nil, err
+		}
+	}
+
+	var events []domain.WebhookEvent
+	if params.Events != nil {
+		events = toWebhookEvents(params.Events)
+	}
+
+	err = hook.Update(&domain.UpdateWebhookParams{
+		Name:     params.Name,
+		URL:      params.URL,
+		Secret:   params.Secret,
+		Events:   events,
+		FolderID: params.FolderID,
+		Enabled:  params.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookStore.Save(ctx, hook); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapWebhookToResponse(hook), nil
+}
+
+func (s *webhookServiceImpl) Delete(ctx context.Context, webhookID string) error {
+	if err := s.webhookStore.DeleteByID(ctx, webhookID); err != nil {
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, webhookID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookServiceImpl) ListDeliveries(ctx context.Context, webhookID string, page, pageSize int) (*dto.WebhookDeliveryListResponse, error) {
+	if _, err := s.find(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, count, err := s.deliveryStore.FindByWebhook(ctx, webhookID, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	data := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		data[i] = mapDeliveryToResponse(delivery)
+	}
+
+	return &dto.WebhookDeliveryListResponse{
+		Data:       data,
+		Pagination: store.CalculatePaginationResult(page, pageSize, count),
+	}, nil
+}
+
+func (s *webhookServiceImpl) SendTest(ctx context.Context, webhookID string) (*dto.WebhookDeliveryResponse, error) {
+	hook, err := s.find(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := domain.NewWebhookDelivery(hook.ID, domain.WebhookPing, &dto.WebhookPingData{
+		WebhookID: hook.ID,
+		Message:   "This is a test event",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Attempts = 1
+	s.attempt(ctx, hook, delivery, 1)
+
+	if err := s.deliveryStore.Save(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return mapDeliveryToResponse(delivery), nil
+}
+
+// Publish runs in the transaction of the change; a hook scoped to several of the folders is notified once.
+func (s *webhookServiceImpl) Publish(ctx context.Context, event domain.WebhookEvent, folderIDs []string, data ...any) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	hooks, err := s.webhookStore.FindSubscribed(ctx, event, folderIDs)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(hooks)*len(data))
+	for _, hook := range hooks {
+		for _, item := range data {
+			delivery, err := domain.NewWebhookDelivery(hook.ID, event, item)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return s.deliveryStore.SaveAll(ctx, deliveries)
+}
+
+func (s *webhookServiceImpl) ProcessDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		deliveries, err := s.deliveryStore.ClaimDue(ctx, time.Now().UTC(), webhookLease, webhookBatchSize)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		for _, delivery := range deliveries {
+			s.process(ctx, delivery)
+		}
+	}
+	return nil
+}
+
+func (s *webhookServiceImpl) process(ctx context.Context, delivery *domain.WebhookDelivery) {
+	hook, err := s.webhookStore.FindByID(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, store.ErrWebhookNotFound):
+		delivery.MarkFailed(0, "", err.Error(), time.Now().UTC(), 0)
+	case err != nil:
+		log.Printf("WEBHOOK_ERROR: couldn't load webhook for delivery %s: %v", delivery.ID, err)
+		delivery.MarkFailed(0, "", err.Error(), time.Now().UTC(), s.maxAttempts)
+	case !hook.Enabled:
+		delivery.MarkFailed(0, "", "webhook is disabled", time.Now().UTC(), 0)
+	default:
+		s.attempt(ctx, hook, delivery, s.maxAttempts)
+	}
+
+	if err := s.deliveryStore.Save(ctx, delivery); err != nil {
+		log.Printf("WEBHOOK_ERROR: couldn't save delivery %s: %v", delivery.ID, err)
+	}
+}
+
+func (s *webhookServiceImpl) attempt(ctx context.Context, hook *domain.Webhook, delivery *domain.WebhookDelivery, maxAttempts int) {
+	response, err := s.sender.Send(ctx, &webhook.Request{
+		URL:        hook.URL,
+		Secret:     hook.Secret,
+		Event:      string(delivery.Event),
+		DeliveryID: delivery.ID,
+		Body:       []byte(delivery.Payload),
+	})
+
+	var statusCode int
+	var body string
+	if response != nil {
+		statusCode, body = response.StatusCode, response.Body
+	}
+
+	now := time.Now().UTC()
+	if err != nil {
+		log.Printf("WEBHOOK_ERROR: attempt %d of delivery %s failed: %v", delivery.Attempts, delivery.ID, err)
+		delivery.MarkFailed(statusCode, body, err.Error(), now, maxAttempts)
+		return
+	}
+	delivery.MarkDelivered(statusCode, body, now)
+}
+
+func (s *webhookServiceImpl) find(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	hook, err := s.webhookStore.FindByID(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			return nil, fmt.Errorf("%w: with ID: %s", err, webhookID)
+		}
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+	return hook, nil
+}
+
+func (s *webhookServiceImpl) checkFolder(ctx context.Context, folderID *string) error {
+	if folderID == nil {
+		return nil
+	}
+	if _, err := s.folderStore.FindByID(ctx, *folderID); err != nil {
+		if errors.Is(err, store.ErrFolderNotFound) {
+			return fmt.Errorf("%w: with ID: %s", err, *folderID)
+		}
+		return fmt.Errorf("db error: %w", err)
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func toWebhookEvents(events []string) []domain.WebhookEvent {
+	result := make([]domain.WebhookEvent, len(events))
+	for i, event := range events {
+		result[i] = domain.WebhookEvent(event)
+	}
+	return result
+}
+
+func webhookTaskData(task *domain.Task, changedFields []string) *dto.WebhookTaskData {
+	return &dto.WebhookTaskData{
+		ID:                task.ID,
+		FolderID:          task.FolderID,
+		SoftName:          task.SoftName,
+		RequestID:         task.RequestID,
+		Description:       task.Description,
+		AssigneeID:        task.AssigneeID,
+		CreatorID:         task.CreatorID,
+		TestEnvDateUpdate: task.TestEnvDateUpdate,
+		CheckDate:         task.CheckDate,
+		CheckStatus:       string(task.CheckStatus),
+		CheckResult:       string(task.CheckResult),
+		Comment:           task.Comment,
+		ChangedFields:     changedFields,
+	}
+}
+
+func webhookFolderData(folder *domain.Folder, taskCount int) *dto.WebhookFolderData {
+	return &dto.WebhookFolderData{
+		ID:             folder.ID,
+		Name:           folder.Name,
+		Description:    folder.Description,
+		ReleaseVersion: folder.ReleaseVersion,
+		OwnerID:        folder.OwnerID,
+		TaskCount:      taskCount,
+	}
+}
+
+func mapWebhookToResponse(hook *domain.Webhook) *dto.WebhookResponse {
+	events := make([]string, len(hook.Events))
+	for i, event := range hook.Events {
+		events[i] = string(event)
+	}
+
+	return &dto.WebhookResponse{
+		ID:        hook.ID,
+		Name:      hook.Name,
+		URL:       hook.URL,
+		Events:    events,
+		FolderID:  hook.FolderID,
+		Enabled:   hook.Enabled,
+		CreatedBy: hook.CreatedBy,
+		CreatedAt: hook.CreatedAt,
+		UpdatedAt: hook.UpdatedAt,
+	}
+}
+
+func mapDeliveryToResponse(delivery *domain.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	return &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Event:          string(delivery.Event),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		Payload:        json.RawMessage(delivery.Payload),
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func NewWebhookService(deps *WebhookServiceDeps) WebhookService {
+	return &webhookServiceImpl{
+		webhookStore:  deps.WebhookStore,
+		deliveryStore: deps.DeliveryStore,
+		folderStore:   deps.FolderStore,
+		sender:        deps.Sender,
+		maxAttempts:   deps.MaxAttempts,
+	}
+}
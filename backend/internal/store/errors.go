@@ -3,9 +3,20 @@ package store
 import "errors"
 
 var (
-	ErrSessionNotFound = errors.New("session not found")
-	ErrStateNotFound   = errors.New("state not found")
-	ErrUserNotFound    = errors.New("user not found")
-	ErrTaskNotFound    = errors.New("task not found")
-	ErrFolderNotFound  = errors.New("folder not found")
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrStateNotFound         = errors.New("state not found")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrTaskNotFound          = errors.New("task not found")
+	ErrFolderNotFound        = errors.New("folder not found")
+	ErrMemberNotFound        = errors.New("folder member not found")
+	ErrReportJobNotFound     = errors.New("report job not found")
+	ErrReportFileNotFound    = errors.New("report file not found")
+	ErrScheduleNotFound      = errors.New("report schedule not found")
+	ErrTemplateNotFound      = errors.New("report template not found")
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrInboxItemNotFound     = errors.New("inbox item not found")
+	ErrSettingsNotFound      = errors.New("notification settings not found")
+	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrEmailTemplateNotFound = errors.New("email template not found")
+	ErrTemplateNameTaken     = errors.New("report template name is already taken")
 )
@@ -0,0 +1,98 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pesos228/bug-tracker/internal/store"
+)
+
+type reportFileStoreImpl struct {
+	dir string
+}
+
+// Save writes to a temporary file and renames it, so readers never see a partial report.
+func (r *reportFileStoreImpl) Save(ctx context.Context, key string, write func(w io.Writer) error) (int64, error) {
+	path, err := r.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := os.CreateTemp(r.dir, key+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	if err := write(file); err != nil {
+		file.Close()
+		return 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+func (r *reportFileStoreImpl) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := r.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, store.ErrReportFileNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (r *reportFileStoreImpl) Delete(ctx context.Context, key string) error {
+	path, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *reportFileStoreImpl) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+		return "", fmt.Errorf("invalid report file key '%s'", key)
+	}
+	return filepath.Join(r.dir, key), nil
+}
+
+func NewReportFileStore(dir string) (store.ReportFileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("couldn't create report directory %s: %w", dir, err)
+	}
+
+	return &reportFileStoreImpl{dir: dir}, nil
+}
@@ -0,0 +1,55 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+)
+
+type emailTemplateStoreImpl struct {
+	db *gorm.DB
+}
+
+func (e *emailTemplateStoreImpl) Save(ctx context.Context, template *domain.EmailTemplate) error {
+	return conn(ctx, e.db).Save(template).Error
+}
+
+func (e *emailTemplateStoreImpl) Find(ctx context.Context, name, locale string) (*domain.EmailTemplate, error) {
+	var template domain.EmailTemplate
+	if err := conn(ctx, e.db).Where("name = ? AND locale = ?", name, locale).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (e *emailTemplateStoreImpl) FindAll(ctx context.Context) ([]*domain.EmailTemplate, error) {
+	var templates []*domain.EmailTemplate
+	if err := conn(ctx, e.db).Order("name ASC, locale ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (e *emailTemplateStoreImpl) Delete(ctx context.Context, name, locale string) error {
+	result := conn(ctx, e.db).Delete(&domain.EmailTemplate{}, "name = ? AND locale = ?", name, locale)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrEmailTemplateNotFound
+	}
+
+	return nil
+}
+
+func NewPsqlEmailTemplateStore(db *gorm.DB) store.EmailTemplateStore {
+	return &emailTemplateStoreImpl{db: db}
+}
@@ -16,7 +16,7 @@ type folderStoreImpl struct {
 
 func (f *folderStoreImpl) FindByID(ctx context.Context, folderID string, preloads ...store.PreloadOption) (*domain.Folder, error) {
 	var folder *domain.Folder
-	query := f.db.WithContext(ctx)
+	query := conn(ctx, f.db)
 	query = PreLoad(query, preloads...)
 
 	result := query.Where("id = ?", folderID).Where("deleted_at is NULL").First(&folder)
@@ -30,27 +30,63 @@ func (f *folderStoreImpl) FindByID(ctx context.Context, folderID string, preload
 	return folder, nil
 }
 
+func (f *folderStoreImpl) FindByFilter(ctx context.Context, filter *store.FolderFilterQuery, preloads ...store.PreloadOption) ([]*domain.Folder, error) {
+	var folders []*domain.Folder
+	query := conn(ctx, f.db)
+	query = PreLoad(query, preloads...)
+
+	query = query.Where("folders.deleted_at IS NULL")
+
+	if len(filter.IDs) > 0 {
+		query = query.Where("folders.id IN (?)", filter.IDs)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("folders.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("folders.created_at < ?", *filter.CreatedTo)
+	}
+
+	if filter.MemberID != "" {
+		members := f.db.Model(&domain.FolderMember{}).Select("folder_id").Where("user_id = ?", filter.MemberID)
+		if len(filter.MemberRoles) > 0 {
+			members = members.Where("role IN (?)", filter.MemberRoles)
+		}
+		query = query.Where("folders.id IN (?)", members)
+	}
+
+	if err := query.Order("folders.created_at ASC").Find(&folders).Error; err != nil {
+		return nil, err
+	}
+
+	return folders, nil
+}
+
 func (f *folderStoreImpl) IsExists(ctx context.Context, folderId string) (bool, error) {
 	var count int64
 
-	if err := f.db.WithContext(ctx).Model(domain.Folder{}).Where("id = ?", folderId).Where("deleted_at is NULL").Count(&count).Error; err != nil {
+	if err := conn(ctx, f.db).Model(domain.Folder{}).Where("id = ?", folderId).Where("deleted_at is NULL").Count(&count).Error; err != nil {
 		return false, err
 	}
 
 	return count > 0, nil
 }
 
-func (f *folderStoreImpl) Search(ctx context.Context, page int, pageSize int, query string) ([]*store.FolderSearchResult, int64, error) {
+func (f *folderStoreImpl) Search(ctx context.Context, params *store.SearchFoldersQuery) ([]*store.FolderSearchResult, int64, error) {
 	var results []*store.FolderSearchResult
 	var count int64
 
-	dbQuery := f.db.WithContext(ctx).Model(&domain.Folder{}).Where("deleted_at is NULL")
+	dbQuery := conn(ctx, f.db).Model(&domain.Folder{}).Where("deleted_at is NULL")
 
-	if query != "" {
-		searchPattern := fmt.Sprintf("%%%s%%", query)
+	if params.Query != "" {
+		searchPattern := fmt.Sprintf("%%%s%%", params.Query)
 		dbQuery = dbQuery.Where("name ILIKE ?", searchPattern)
 	}
 
+	if params.MemberID != "" {
+		dbQuery = dbQuery.Where("folders.id IN (?)", f.db.Model(&domain.FolderMember{}).Select("folder_id").Where("user_id = ?", params.MemberID))
+	}
+
 	if err := dbQuery.Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
@@ -60,11 +96,11 @@ func (f *folderStoreImpl) Search(ctx context.Context, page int, pageSize int, qu
 	}
 
 	err := dbQuery.
-		Select("folders.id, folders.name, folders.created_by, folders.created_at, COUNT(tasks.id) as task_count").
+		Select("folders.*, COUNT(tasks.id) as task_count").
 		Joins("LEFT JOIN tasks ON tasks.folder_id = folders.id").
 		Group("folders.id").
 		Order("created_at DESC").
-		Scopes(store.PaginationWithParams(page, pageSize)).
+		Scopes(store.PaginationWithParams(params.Page, params.PageSize)).
 		Find(&results).Error
 
 	if err != nil {
@@ -75,7 +111,7 @@ func (f *folderStoreImpl) Search(ctx context.Context, page int, pageSize int, qu
 }
 
 func (f *folderStoreImpl) Save(ctx context.Context, folder *domain.Folder) error {
-	return f.db.WithContext(ctx).Save(folder).Error
+	return conn(ctx, f.db).Save(folder).Error
 }
 
 func NewPsqlFolderStore(db *gorm.DB) store.FolderStore {
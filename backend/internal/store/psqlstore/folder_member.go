@@ -0,0 +1,105 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+)
+
+type folderMemberStoreImpl struct {
+	db *gorm.DB
+}
+
+func (f *folderMemberStoreImpl) Find(ctx context.Context, folderID, userID string) (*domain.FolderMember, error) {
+	var member domain.FolderMember
+	result := conn(ctx, f.db).
+		Where("folder_id = ? AND user_id = ?", folderID, userID).
+		First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, store.ErrMemberNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &member, nil
+}
+
+func (f *folderMemberStoreImpl) FindByFolderIDWithUserInfo(ctx context.Context, folderID string) ([]*store.FolderMemberWithUserInfo, error) {
+	var members []*store.FolderMemberWithUserInfo
+	result := conn(ctx, f.db).Model(&domain.FolderMember{}).
+		Select("folder_members.*, users.first_name, users.last_name").
+		Joins("LEFT JOIN users ON users.id = folder_members.user_id").
+		Where("folder_members.folder_id = ?", folderID).
+		Order("folder_members.created_at ASC").
+		Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return members, nil
+}
+
+func (f *folderMemberStoreImpl) CountByRole(ctx context.Context, folderID string, role domain.FolderRole) (int64, error) {
+	var count int64
+	err := conn(ctx, f.db).Model(&domain.FolderMember{}).
+		Where("folder_id = ? AND role = ?", folderID, role).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (f *folderMemberStoreImpl) HasAnyRole(ctx context.Context, userID string, roles []domain.FolderRole) (bool, error) {
+	var count int64
+	err := conn(ctx, f.db).Model(&domain.FolderMember{}).
+		Joins("JOIN folders f ON folder_members.folder_id = f.id").
+		Where("f.deleted_at IS NULL").
+		Where("folder_members.user_id = ? AND folder_members.role IN (?)", userID, roles).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (f *folderMemberStoreImpl) FindByUserID(ctx context.Context, userID string) ([]*domain.FolderMember, error) {
+	var members []*domain.FolderMember
+	result := conn(ctx, f.db).
+		Joins("JOIN folders f ON folder_members.folder_id = f.id").
+		Where("f.deleted_at IS NULL").
+		Where("folder_members.user_id = ?", userID).
+		Order("folder_members.created_at ASC").
+		Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return members, nil
+}
+
+func (f *folderMemberStoreImpl) Delete(ctx context.Context, folderID, userID string) error {
+	result := conn(ctx, f.db).Delete(&domain.FolderMember{}, "folder_id = ? AND user_id = ?", folderID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (f *folderMemberStoreImpl) Save(ctx context.Context, member *domain.FolderMember) error {
+	return conn(ctx, f.db).Save(member).Error
+}
+
+func NewPsqlFolderMemberStore(db *gorm.DB) store.FolderMemberStore {
+	return &folderMemberStoreImpl{db: db}
+}
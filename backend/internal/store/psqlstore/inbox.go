@@ -0,0 +1,88 @@
+package psqlstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type inboxStoreImpl struct {
+	db *gorm.DB
+}
+
+func (i *inboxStoreImpl) SaveAll(ctx context.Context, items []*domain.InboxItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return conn(ctx, i.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&items).Error
+}
+
+func (i *inboxStoreImpl) FindByUser(ctx context.Context, params *store.InboxQuery) ([]*domain.InboxItem, int64, error) {
+	var items []*domain.InboxItem
+	var count int64
+
+	query := conn(ctx, i.db).Model(&domain.InboxItem{}).Where("user_id = ?", params.UserID)
+	if params.UnreadOnly {
+		query = query.Where("read = ?", false)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Scopes(store.PaginationWithParams(params.Page, params.PageSize)).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, count, nil
+}
+
+func (i *inboxStoreImpl) CountUnread(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := conn(ctx, i.db).Model(&domain.InboxItem{}).
+		Where("user_id = ? AND read = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+func (i *inboxStoreImpl) MarkRead(ctx context.Context, userID, itemID string, now time.Time) error {
+	result := conn(ctx, i.db).Model(&domain.InboxItem{}).
+		Where("id = ? AND user_id = ?", itemID, userID).
+		Updates(map[string]interface{}{
+			"read":    true,
+			"read_at": gorm.Expr("COALESCE(read_at, ?)", now),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrInboxItemNotFound
+	}
+
+	return nil
+}
+
+func (i *inboxStoreImpl) MarkAllRead(ctx context.Context, userID string, now time.Time) (int64, error) {
+	result := conn(ctx, i.db).Model(&domain.InboxItem{}).
+		Where("user_id = ? AND read = ?", userID, false).
+		Updates(map[string]interface{}{
+			"read":    true,
+			"read_at": now,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func NewPsqlInboxStore(db *gorm.DB) store.InboxStore {
+	return &inboxStoreImpl{db: db}
+}
@@ -0,0 +1,50 @@
+package psqlstore
+
+import (
+	"context"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mentionStoreImpl struct {
+	db *gorm.DB
+}
+
+func (m *mentionStoreImpl) SaveAll(ctx context.Context, mentions []*domain.TaskMention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	return conn(ctx, m.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&mentions).Error
+}
+
+func (m *mentionStoreImpl) IsMentioned(ctx context.Context, taskID, userID string) (bool, error) {
+	var count int64
+	err := conn(ctx, m.db).Model(&domain.TaskMention{}).
+		Where("task_id = ? AND user_id = ?", taskID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (m *mentionStoreImpl) DeleteExcept(ctx context.Context, taskID string, userIDs []string) error {
+	query := conn(ctx, m.db).Where("task_id = ?", taskID)
+	if len(userIDs) > 0 {
+		query = query.Where("user_id NOT IN ?", userIDs)
+	}
+	return query.Delete(&domain.TaskMention{}).Error
+}
+
+func (m *mentionStoreImpl) CountUsers(ctx context.Context, taskID string) (int64, error) {
+	var count int64
+	err := conn(ctx, m.db).Model(&domain.TaskMention{}).
+		Where("task_id = ?", taskID).
+		Distinct("user_id").
+		Count(&count).Error
+	return count, err
+}
+
+func NewPsqlMentionStore(db *gorm.DB) store.MentionStore {
+	return &mentionStoreImpl{db: db}
+}
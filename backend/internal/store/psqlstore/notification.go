@@ -0,0 +1,137 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationStoreImpl struct {
+	db *gorm.DB
+}
+
+func (n *notificationStoreImpl) Save(ctx context.Context, notification *domain.Notification) error {
+	return conn(ctx, n.db).Save(notification).Error
+}
+
+func (n *notificationStoreImpl) SaveAll(ctx context.Context, notifications []*domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return conn(ctx, n.db).Create(&notifications).Error
+}
+
+func (n *notificationStoreImpl) FindByID(ctx context.Context, notificationID string) (*domain.Notification, error) {
+	var notification domain.Notification
+	if err := conn(ctx, n.db).Where("id = ?", notificationID).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// ClaimDue leases entries, so a crashed worker's notifications become due again.
+func (n *notificationStoreImpl) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Notification, error) {
+	var notifications []*domain.Notification
+
+	err := conn(ctx, n.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.NotificationPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&notifications).Error
+		if err != nil || len(notifications) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(notifications))
+		for i, notification := range notifications {
+			notification.Attempts++
+			notification.NextAttemptAt = now.Add(lease)
+			ids[i] = notification.ID
+		}
+
+		return tx.Model(&domain.Notification{}).
+			Where("id IN (?)", ids).
+			Updates(map[string]interface{}{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (n *notificationStoreImpl) FindDead(ctx context.Context, page, pageSize int) ([]*domain.Notification, int64, error) {
+	var notifications []*domain.Notification
+	var count int64
+
+	query := conn(ctx, n.db).Model(&domain.Notification{}).Where("status = ?", domain.NotificationDead)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("updated_at DESC").
+		Scopes(store.PaginationWithParams(page, pageSize)).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, count, nil
+}
+
+func (n *notificationStoreImpl) FindDigestRecipients(ctx context.Context) ([]string, error) {
+	var recipients []string
+	err := conn(ctx, n.db).Model(&domain.Notification{}).
+		Where("status = ?", domain.NotificationDigest).
+		Distinct().
+		Pluck("recipient_id", &recipients).Error
+	return recipients, err
+}
+
+// ClaimDigest leases held entries, so the digest is sent without holding row locks.
+func (n *notificationStoreImpl) ClaimDigest(ctx context.Context, recipientID string, now time.Time, lease time.Duration) ([]*domain.Notification, error) {
+	var notifications []*domain.Notification
+
+	err := conn(ctx, n.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND recipient_id = ? AND next_attempt_at <= ?", domain.NotificationDigest, recipientID, now).
+			Order("created_at ASC").
+			Find(&notifications).Error
+		if err != nil || len(notifications) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(notifications))
+		for i, notification := range notifications {
+			notification.NextAttemptAt = now.Add(lease)
+			ids[i] = notification.ID
+		}
+
+		return tx.Model(&domain.Notification{}).
+			Where("id IN (?)", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func NewPsqlNotificationStore(db *gorm.DB) store.NotificationStore {
+	return &notificationStoreImpl{db: db}
+}
@@ -0,0 +1,34 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+)
+
+type notificationSettingsStoreImpl struct {
+	db *gorm.DB
+}
+
+func (n *notificationSettingsStoreImpl) Save(ctx context.Context, settings *domain.NotificationSettings) error {
+	return conn(ctx, n.db).Save(settings).Error
+}
+
+func (n *notificationSettingsStoreImpl) FindByUser(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	var settings domain.NotificationSettings
+	if err := conn(ctx, n.db).Where("user_id = ?", userID).First(&settings).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrSettingsNotFound
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func NewPsqlNotificationSettingsStore(db *gorm.DB) store.NotificationSettingsStore {
+	return &notificationSettingsStoreImpl{db: db}
+}
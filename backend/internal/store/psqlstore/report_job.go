@@ -0,0 +1,90 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reportJobStoreImpl struct {
+	db *gorm.DB
+}
+
+func (r *reportJobStoreImpl) Save(ctx context.Context, job *domain.ReportJob) error {
+	return conn(ctx, r.db).Save(job).Error
+}
+
+func (r *reportJobStoreImpl) FindByID(ctx context.Context, jobID string) (*domain.ReportJob, error) {
+	var job domain.ReportJob
+	if err := conn(ctx, r.db).Where("id = ?", jobID).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrReportJobNotFound
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *reportJobStoreImpl) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*domain.ReportJob, error) {
+	var job domain.ReportJob
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND leased_until < ?)", domain.ReportJobPending, domain.ReportJobRunning, now).
+			Order("created_at ASC").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		leasedUntil := now.Add(lease)
+		job.Status = domain.ReportJobRunning
+		job.StartedAt = &now
+		job.LeasedUntil = &leasedUntil
+		job.Attempts++
+
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":       job.Status,
+			"started_at":   job.StartedAt,
+			"leased_until": job.LeasedUntil,
+			"attempts":     job.Attempts,
+		}).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrReportJobNotFound
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *reportJobStoreImpl) ExtendLease(ctx context.Context, jobID string, until time.Time) error {
+	return conn(ctx, r.db).Model(&domain.ReportJob{}).
+		Where("id = ? AND status = ?", jobID, domain.ReportJobRunning).
+		Update("leased_until", until).Error
+}
+
+func (r *reportJobStoreImpl) DeleteExpired(ctx context.Context, now time.Time) ([]*domain.ReportJob, error) {
+	var jobs []*domain.ReportJob
+	err := conn(ctx, r.db).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "file_key"}}}).
+		Where("expires_at IS NOT NULL AND expires_at < ?", now).
+		Delete(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func NewPsqlReportJobStore(db *gorm.DB) store.ReportJobStore {
+	return &reportJobStoreImpl{db: db}
+}
@@ -0,0 +1,117 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reportScheduleStoreImpl struct {
+	db *gorm.DB
+}
+
+func (r *reportScheduleStoreImpl) Save(ctx context.Context, schedule *domain.ReportSchedule) error {
+	return conn(ctx, r.db).Save(schedule).Error
+}
+
+func (r *reportScheduleStoreImpl) FindByID(ctx context.Context, scheduleID string) (*domain.ReportSchedule, error) {
+	var schedule domain.ReportSchedule
+	if err := conn(ctx, r.db).Where("id = ?", scheduleID).First(&schedule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrScheduleNotFound
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *reportScheduleStoreImpl) FindByCreator(ctx context.Context, userID string) ([]*domain.ReportSchedule, error) {
+	var schedules []*domain.ReportSchedule
+	err := conn(ctx, r.db).
+		Where("created_by = ?", userID).
+		Order("created_at DESC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (r *reportScheduleStoreImpl) FindDueForUpdate(ctx context.Context, now time.Time) (*domain.ReportSchedule, error) {
+	var schedule domain.ReportSchedule
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("enabled = ? AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC").
+		First(&schedule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrScheduleNotFound
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *reportScheduleStoreImpl) Delete(ctx context.Context, scheduleID string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.ReportScheduleRun{}, "schedule_id = ?", scheduleID).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.ReportSchedule{}, "id = ?", scheduleID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return store.ErrScheduleNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *reportScheduleStoreImpl) RecordRun(ctx context.Context, schedule *domain.ReportSchedule, run *domain.ReportScheduleRun) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(schedule).
+			Select("last_run_at", "last_error", "enabled", "updated_at").
+			Updates(schedule).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(run).Error
+	})
+}
+
+func (r *reportScheduleStoreImpl) FindRuns(ctx context.Context, scheduleID string, page, pageSize int) ([]*domain.ReportScheduleRun, int64, error) {
+	var runs []*domain.ReportScheduleRun
+	var count int64
+
+	query := conn(ctx, r.db).Model(&domain.ReportScheduleRun{}).Where("schedule_id = ?", scheduleID)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("started_at DESC").
+		Scopes(store.PaginationWithParams(page, pageSize)).
+		Find(&runs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return runs, count, nil
+}
+
+func NewPsqlReportScheduleStore(db *gorm.DB) store.ReportScheduleStore {
+	return &reportScheduleStoreImpl{db: db}
+}
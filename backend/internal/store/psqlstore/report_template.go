@@ -0,0 +1,98 @@
+package psqlstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+)
+
+const uniqueViolationCode = "23505"
+
+type reportTemplateStoreImpl struct {
+	db *gorm.DB
+}
+
+func (r *reportTemplateStoreImpl) Save(ctx context.Context, template *domain.ReportTemplate) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if template.IsDefault {
+			err := tx.Model(&domain.ReportTemplate{}).
+				Where("is_default = ? AND id <> ?", true, template.ID).
+				Update("is_default", false).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Save(template).Error; err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+				return store.ErrTemplateNameTaken
+			}
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (r *reportTemplateStoreImpl) FindByID(ctx context.Context, templateID string) (*domain.ReportTemplate, error) {
+	var template domain.ReportTemplate
+	if err := conn(ctx, r.db).Where("id = ?", templateID).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *reportTemplateStoreImpl) FindAll(ctx context.Context) ([]*domain.ReportTemplate, error) {
+	var templates []*domain.ReportTemplate
+	if err := conn(ctx, r.db).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *reportTemplateStoreImpl) FindDefault(ctx context.Context) (*domain.ReportTemplate, error) {
+	var template domain.ReportTemplate
+	if err := conn(ctx, r.db).Where("is_default = ?", true).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *reportTemplateStoreImpl) Delete(ctx context.Context, templateID string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.Folder{}).
+			Where("report_template_id = ?", templateID).
+			Update("report_template_id", nil).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.ReportTemplate{}, "id = ?", templateID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return store.ErrTemplateNotFound
+		}
+
+		return nil
+	})
+}
+
+func NewPsqlReportTemplateStore(db *gorm.DB) store.ReportTemplateStore {
+	return &reportTemplateStoreImpl{db: db}
+}
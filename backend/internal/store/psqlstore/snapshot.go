@@ -0,0 +1,62 @@
+package psqlstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type snapshotStoreImpl struct {
+	db *gorm.DB
+}
+
+func (s *snapshotStoreImpl) Upsert(ctx context.Context, snapshots []*domain.FolderSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	return conn(ctx, s.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "folder_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"not_checked_count",
+			"checked_count",
+			"partially_checked_count",
+			"failed_count",
+		}),
+	}).CreateInBatches(snapshots, 100).Error
+}
+
+func (s *snapshotStoreImpl) FindByFolderID(ctx context.Context, folderID string, from, to time.Time) ([]*domain.FolderSnapshot, error) {
+	var snapshots []*domain.FolderSnapshot
+
+	err := conn(ctx, s.db).
+		Where("folder_id = ? AND date BETWEEN ? AND ?", folderID, from, to).
+		Order("date ASC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+func (s *snapshotStoreImpl) FindLatest(ctx context.Context) ([]*domain.FolderSnapshot, error) {
+	var snapshots []*domain.FolderSnapshot
+
+	err := conn(ctx, s.db).
+		Raw("SELECT DISTINCT ON (folder_id) * FROM folder_snapshots ORDER BY folder_id, date DESC").
+		Scan(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+func NewPsqlSnapshotStore(db *gorm.DB) store.SnapshotStore {
+	return &snapshotStoreImpl{db: db}
+}
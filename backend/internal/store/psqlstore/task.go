@@ -14,25 +14,46 @@ type taskStoreImpl struct {
 	db *gorm.DB
 }
 
-func (t *taskStoreImpl) FindByFolderIdWithUserInfo(ctx context.Context, folderID string) ([]*store.TasksWithUserInfo, error) {
-	var tasks []*store.TasksWithUserInfo
-	result := t.db.WithContext(ctx).Model(domain.Task{}).
+var taskSortColumns = map[string]string{
+	"createdAt":         "tasks.created_at",
+	"softName":          "tasks.soft_name",
+	"requestId":         "tasks.request_id",
+	"testEnvDateUpdate": "tasks.test_env_date_update",
+	"checkDate":         "tasks.check_date",
+	"checkStatus":       "tasks.check_status",
+	"checkResult":       "tasks.check_result",
+}
+
+func (t *taskStoreImpl) IterateByFilterWithUserInfo(ctx context.Context, params *store.TaskFilterQuery, fn func(task *store.TasksWithUserInfo) error) error {
+	db := conn(ctx, t.db)
+	dbQuery := db.Model(domain.Task{}).
 		Select("tasks.*, users.first_name, users.last_name").
-		Joins("LEFT JOIN users ON users.id = tasks.assignee_id").
-		Where("tasks.folder_id = ?", folderID).
-		Order("tasks.created_at DESC").
-		Find(&tasks)
-	if result.Error != nil {
-		return nil, result.Error
+		Joins("LEFT JOIN users ON users.id = tasks.assignee_id")
+
+	rows, err := applyTaskFilter(dbQuery, params).Rows()
+	if err != nil {
+		return err
 	}
-	return tasks, nil
+	defer rows.Close()
+
+	for rows.Next() {
+		var task store.TasksWithUserInfo
+		if err := db.ScanRows(rows, &task); err != nil {
+			return err
+		}
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
 func (t *taskStoreImpl) SearchByUserID(ctx context.Context, params *store.SearchTaskQueryByUserID) ([]*domain.Task, int64, error) {
 	var tasks []*domain.Task
 	var count int64
 
-	dbQuery := t.db.WithContext(ctx).Model(&domain.Task{}).Where("assignee_id = ?", params.AssigneeID).
+	dbQuery := conn(ctx, t.db).Model(&domain.Task{}).Where("assignee_id = ?", params.AssigneeID).
 		Joins("JOIN folders f ON tasks.folder_id = f.id").
 		Where("f.deleted_at IS NULL")
 
@@ -61,16 +82,22 @@ func (t *taskStoreImpl) SearchByUserID(ctx context.Context, params *store.Search
 	return tasks, count, nil
 }
 
-func (t *taskStoreImpl) GetTaskCountsForUsers(ctx context.Context, userIDs []string, inProgressStatuses, completedStatuses []domain.CheckStatus) ([]*store.TaskCountResult, error) {
+func (t *taskStoreImpl) GetTaskCountsForUsers(ctx context.Context, query *store.TaskCountsQuery) ([]*store.TaskCountResult, error) {
 	var tasksCount []*store.TaskCountResult
 
-	inProgressExpr := gorm.Expr("COUNT(CASE WHEN check_status IN (?) THEN 1 END)", inProgressStatuses)
-	completedExpr := gorm.Expr("COUNT(CASE WHEN check_status IN (?) THEN 1 END)", completedStatuses)
+	inProgressExpr := gorm.Expr("COUNT(CASE WHEN tasks.check_status IN (?) THEN 1 END)", query.InProgressStatuses)
+	completedExpr := gorm.Expr("COUNT(CASE WHEN tasks.check_status IN (?) THEN 1 END)", query.CompletedStatuses)
+
+	dbQuery := conn(ctx, t.db).Model(&domain.Task{}).
+		Select("tasks.assignee_id as user_id, ? as in_progress_tasks_count, ? as completed_tasks_count", inProgressExpr, completedExpr).
+		Joins("JOIN folders f ON f.id = tasks.folder_id AND f.deleted_at IS NULL").
+		Where("tasks.assignee_id IN (?)", query.UserIDs)
+
+	if query.FolderID != "" {
+		dbQuery = dbQuery.Where("tasks.folder_id = ?", query.FolderID)
+	}
 
-	err := t.db.WithContext(ctx).Model(&domain.Task{}).
-		Select("assignee_id as user_id, ? as in_progress_tasks_count, ? as completed_tasks_count", inProgressExpr, completedExpr).
-		Where("assignee_id IN (?)", userIDs).
-		Group("assignee_id").Find(&tasksCount).Error
+	err := dbQuery.Group("tasks.assignee_id").Find(&tasksCount).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task counts for users: %w", err)
@@ -79,23 +106,140 @@ func (t *taskStoreImpl) GetTaskCountsForUsers(ctx context.Context, userIDs []str
 	return tasksCount, nil
 }
 
-func (t *taskStoreImpl) DeleteByID(ctx context.Context, taskID string) error {
-	result := t.db.WithContext(ctx).Delete(&domain.Task{}, "id = ?", taskID)
-	if result.Error != nil {
-		return result.Error
+func (t *taskStoreImpl) GetTeamWorkload(ctx context.Context, params *store.TeamWorkloadQuery) ([]*store.TeamWorkloadResult, error) {
+	var workload []*store.TeamWorkloadResult
+
+	createdInPeriod := gorm.Expr("tasks.created_at >= ? AND tasks.created_at < ?", params.From, params.To)
+	checkedInPeriod := gorm.Expr("tasks.check_date >= ? AND tasks.check_date < ? AND tasks.check_status <> ?", params.From, params.To, domain.NotChecked)
+
+	dbQuery := conn(ctx, t.db).Model(&domain.Task{}).
+		Select(`tasks.assignee_id as user_id, users.first_name, users.last_name,
+			COUNT(CASE WHEN ? THEN 1 END) as assigned_count,
+			COUNT(CASE WHEN ? THEN 1 END) as checked_count,
+			COUNT(CASE WHEN ? AND tasks.check_status = ? THEN 1 END) as failed_count,
+			COUNT(CASE WHEN ? AND tasks.check_result = ? THEN 1 END) as warning_count,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM GREATEST(tasks.check_date::timestamptz - tasks.created_at, interval '0')))
+				FILTER (WHERE ?) as median_check_seconds`,
+			createdInPeriod,
+			checkedInPeriod,
+			checkedInPeriod, domain.Failed,
+			checkedInPeriod, domain.Warning,
+			checkedInPeriod).
+		Joins("JOIN folders f ON tasks.folder_id = f.id").
+		Joins("LEFT JOIN users ON users.id = tasks.assignee_id").
+		Where("f.deleted_at IS NULL")
+
+	if params.FolderID != "" {
+		dbQuery = dbQuery.Where("tasks.folder_id = ?", params.FolderID)
+	}
+
+	err := dbQuery.
+		Group("tasks.assignee_id, users.first_name, users.last_name").
+		Order("users.last_name, users.first_name").
+		Find(&workload).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team workload: %w", err)
+	}
+
+	return workload, nil
+}
+
+func (t *taskStoreImpl) GetFolderStats(ctx context.Context, folderID string) (*store.FolderStatsResult, error) {
+	var stats store.FolderStatsResult
+	folderTasks := conn(ctx, t.db).Model(&domain.Task{}).Where("tasks.folder_id = ?", folderID).Session(&gorm.Session{})
+
+	err := folderTasks.
+		Select("check_status as value, COUNT(*) as count").
+		Group("check_status").
+		Find(&stats.StatusCounts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks by status: %w", err)
+	}
+
+	err = folderTasks.
+		Select("check_result as value, COUNT(*) as count").
+		Where("check_result IS NOT NULL AND check_result <> ''").
+		Group("check_result").
+		Find(&stats.ResultCounts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks by result: %w", err)
+	}
+
+	err = folderTasks.
+		Select(`tasks.assignee_id as user_id, users.first_name, users.last_name,
+			COUNT(*) as total_count,
+			COUNT(CASE WHEN tasks.check_status = ? THEN 1 END) as not_checked_count,
+			COUNT(CASE WHEN tasks.check_status = ? THEN 1 END) as failed_count`, domain.NotChecked, domain.Failed).
+		Joins("LEFT JOIN users ON users.id = tasks.assignee_id").
+		Group("tasks.assignee_id, users.first_name, users.last_name").
+		Order("users.last_name, users.first_name").
+		Find(&stats.Assignees).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignee breakdown: %w", err)
+	}
+
+	var oldest []*domain.Task
+	err = folderTasks.
+		Where("tasks.check_status = ?", domain.NotChecked).
+		Order("tasks.created_at ASC").
+		Limit(1).
+		Find(&oldest).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oldest unchecked task: %w", err)
+	}
+	if len(oldest) > 0 {
+		stats.OldestUnchecked = oldest[0]
+	}
+
+	err = folderTasks.
+		Select("AVG(EXTRACT(EPOCH FROM GREATEST(tasks.check_date::timestamptz - tasks.created_at, interval '0')))").
+		Where("tasks.check_date IS NOT NULL").
+		Scan(&stats.AvgCheckSeconds).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get average check time: %w", err)
 	}
-	if result.RowsAffected == 0 {
-		return store.ErrTaskNotFound
+
+	return &stats, nil
+}
+
+func (t *taskStoreImpl) GetStatusCountsByFolder(ctx context.Context) ([]*store.FolderStatusCountResult, error) {
+	var counts []*store.FolderStatusCountResult
+
+	err := conn(ctx, t.db).Model(&domain.Task{}).
+		Select("tasks.folder_id, tasks.check_status, COUNT(*) as count").
+		Joins("JOIN folders f ON tasks.folder_id = f.id").
+		Where("f.deleted_at IS NULL").
+		Group("tasks.folder_id, tasks.check_status").
+		Find(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks by folder: %w", err)
 	}
 
-	return nil
+	return counts, nil
+}
+
+func (t *taskStoreImpl) DeleteByID(ctx context.Context, taskID string) error {
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&domain.TaskMention{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.Task{}, "id = ?", taskID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return store.ErrTaskNotFound
+		}
+		return nil
+	})
 }
 
 func (t *taskStoreImpl) SearchByFolderID(ctx context.Context, params *store.SearchTaskQueryByFolderID) ([]*domain.Task, int64, error) {
 	var tasks []*domain.Task
 	var count int64
 
-	dbQuery := t.db.WithContext(ctx).Model(&domain.Task{}).Where("folder_id = ?", params.FolderID).
+	dbQuery := conn(ctx, t.db).Model(&domain.Task{}).Where("folder_id = ?", params.FolderID).
 		Joins("JOIN folders f ON tasks.folder_id = f.id").
 		Where("f.deleted_at IS NULL")
 
@@ -126,7 +270,7 @@ func (t *taskStoreImpl) SearchByFolderID(ctx context.Context, params *store.Sear
 
 func (t *taskStoreImpl) FindById(ctx context.Context, taskId string) (*domain.Task, error) {
 	var task domain.Task
-	result := t.db.WithContext(ctx).First(&task, "id = ?", taskId)
+	result := conn(ctx, t.db).First(&task, "id = ?", taskId)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, store.ErrTaskNotFound
@@ -141,7 +285,7 @@ func (t *taskStoreImpl) FindByUserId(ctx context.Context, page, pageSize int, us
 	var tasks []*domain.Task
 	var count int64
 
-	query := t.db.WithContext(ctx).Model(&domain.Task{}).Where("user_id = ?", userId)
+	query := conn(ctx, t.db).Model(&domain.Task{}).Where("user_id = ?", userId)
 
 	if err := query.Count(&count).Error; err != nil {
 		return nil, 0, err
@@ -158,8 +302,74 @@ func (t *taskStoreImpl) FindByUserId(ctx context.Context, page, pageSize int, us
 	return tasks, count, nil
 }
 
+func (t *taskStoreImpl) FindByFilter(ctx context.Context, params *store.TaskFilterQuery) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+
+	dbQuery := conn(ctx, t.db).Model(&domain.Task{})
+
+	if err := applyTaskFilter(dbQuery, params).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (t *taskStoreImpl) FindByIDs(ctx context.Context, taskIDs []string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+
+	if err := conn(ctx, t.db).Where("id IN (?)", taskIDs).Order("created_at ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func applyTaskFilter(dbQuery *gorm.DB, params *store.TaskFilterQuery) *gorm.DB {
+	dbQuery = dbQuery.Where("tasks.folder_id = ?", params.FolderID)
+
+	if len(params.CheckStatuses) > 0 {
+		dbQuery = dbQuery.Where("tasks.check_status IN (?)", params.CheckStatuses)
+	}
+	if len(params.CheckResults) > 0 {
+		dbQuery = dbQuery.Where("tasks.check_result IN (?)", params.CheckResults)
+	}
+	if len(params.AssigneeIDs) > 0 {
+		dbQuery = dbQuery.Where("tasks.assignee_id IN (?)", params.AssigneeIDs)
+	}
+	if params.TestEnvDateFrom != nil {
+		dbQuery = dbQuery.Where("tasks.test_env_date_update >= ?", *params.TestEnvDateFrom)
+	}
+	if params.TestEnvDateTo != nil {
+		dbQuery = dbQuery.Where("tasks.test_env_date_update <= ?", *params.TestEnvDateTo)
+	}
+	if params.CheckDateFrom != nil {
+		dbQuery = dbQuery.Where("tasks.check_date >= ?", *params.CheckDateFrom)
+	}
+	if params.CheckDateTo != nil {
+		dbQuery = dbQuery.Where("tasks.check_date <= ?", *params.CheckDateTo)
+	}
+
+	column, ok := taskSortColumns[params.Sort]
+	if !ok {
+		column = taskSortColumns["createdAt"]
+	}
+	direction := "ASC"
+	if params.SortDesc {
+		direction = "DESC"
+	}
+
+	return dbQuery.Order(fmt.Sprintf("%s %s NULLS LAST, tasks.id", column, direction))
+}
+
+func (t *taskStoreImpl) SaveAll(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	return conn(ctx, t.db).CreateInBatches(tasks, 100).Error
+}
+
 func (t *taskStoreImpl) Save(ctx context.Context, task *domain.Task) error {
-	return t.db.WithContext(ctx).Save(task).Error
+	return conn(ctx, t.db).Save(task).Error
 }
 
 func NewPsqlTaskStore(db *gorm.DB) store.TaskStore {
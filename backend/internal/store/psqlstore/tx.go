@@ -0,0 +1,35 @@
+package psqlstore
+
+import (
+	"context"
+
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManagerImpl struct {
+	db *gorm.DB
+}
+
+func (t *txManagerImpl) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+func NewPsqlTxManager(db *gorm.DB) store.TxManager {
+	return &txManagerImpl{db: db}
+}
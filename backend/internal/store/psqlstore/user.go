@@ -9,6 +9,7 @@ import (
 	"github.com/pesos228/bug-tracker/internal/domain"
 	"github.com/pesos228/bug-tracker/internal/store"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userStoreImpl struct {
@@ -19,7 +20,7 @@ func (u *userStoreImpl) Search(ctx context.Context, params *store.SearchUsersQue
 	var users []*domain.User
 	var count int64
 
-	dbQuery := u.db.WithContext(ctx).Model(&domain.User{})
+	dbQuery := conn(ctx, u.db).Model(&domain.User{})
 
 	if params.FullName != "" {
 		words := strings.Fields(params.FullName)
@@ -49,10 +50,19 @@ func (u *userStoreImpl) Search(ctx context.Context, params *store.SearchUsersQue
 	return users, count, nil
 }
 
+func (u *userStoreImpl) FindByFullName(ctx context.Context, fullName string) ([]*domain.User, error) {
+	var users []*domain.User
+	err := conn(ctx, u.db).
+		Where("lower(first_name || ' ' || last_name) = lower(?)", fullName).
+		Order("id").
+		Find(&users).Error
+	return users, err
+}
+
 func (u *userStoreImpl) IsExists(ctx context.Context, userId string) (bool, error) {
 	var count int64
 
-	if err := u.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", userId).Count(&count).Error; err != nil {
+	if err := conn(ctx, u.db).Model(&domain.User{}).Where("id = ?", userId).Count(&count).Error; err != nil {
 		return false, err
 	}
 
@@ -63,7 +73,7 @@ func (u *userStoreImpl) FindAll(ctx context.Context, page, pageSize int, preload
 	var users []*domain.User
 	var count int64
 
-	query := u.db.WithContext(ctx).Model(&domain.User{})
+	query := conn(ctx, u.db).Model(&domain.User{})
 	query = PreLoad(query, preloads...)
 
 	if err := query.Count(&count).Error; err != nil {
@@ -82,10 +92,23 @@ func (u *userStoreImpl) FindAll(ctx context.Context, page, pageSize int, preload
 	return users, count, nil
 }
 
+func (u *userStoreImpl) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var user domain.User
+
+	if err := conn(ctx, u.db).First(&user, "lower(email) = lower(?)", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (u *userStoreImpl) FindById(ctx context.Context, userId string, preloads ...store.PreloadOption) (*domain.User, error) {
 	var user domain.User
 
-	query := u.db.WithContext(ctx)
+	query := conn(ctx, u.db)
 	query = PreLoad(query, preloads...)
 
 	result := query.First(&user, "id = ?", userId)
@@ -108,7 +131,28 @@ func PreLoad(query *gorm.DB, preloads ...store.PreloadOption) *gorm.DB {
 }
 
 func (u *userStoreImpl) Save(ctx context.Context, user *domain.User) error {
-	return u.db.WithContext(ctx).Save(user).Error
+	return conn(ctx, u.db).Save(user).Error
+}
+
+// SyncIdentity inserts the user or updates only the columns owned by the identity provider.
+func (u *userStoreImpl) SyncIdentity(ctx context.Context, user *domain.User) error {
+	return conn(ctx, u.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "first_name", "last_name", "locale", "roles"}),
+	}).Create(user).Error
+}
+
+func (u *userStoreImpl) SaveProfile(ctx context.Context, user *domain.User) error {
+	result := conn(ctx, u.db).Model(user).
+		Select("preferred_locale", "chat_handles").
+		Updates(user)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrUserNotFound
+	}
+	return nil
 }
 
 func NewPsqlUserStore(db *gorm.DB) store.UserStore {
@@ -0,0 +1,161 @@
+package psqlstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookStoreImpl struct {
+	db *gorm.DB
+}
+
+func (w *webhookStoreImpl) Save(ctx context.Context, webhook *domain.Webhook) error {
+	return conn(ctx, w.db).Save(webhook).Error
+}
+
+func (w *webhookStoreImpl) FindByID(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := conn(ctx, w.db).Where("id = ?", webhookID).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, store.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookStoreImpl) FindAll(ctx context.Context, page, pageSize int) ([]*domain.Webhook, int64, error) {
+	var webhooks []*domain.Webhook
+	var count int64
+
+	query := conn(ctx, w.db).Model(&domain.Webhook{})
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Scopes(store.PaginationWithParams(page, pageSize)).
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, count, nil
+}
+
+func (w *webhookStoreImpl) FindSubscribed(ctx context.Context, event domain.WebhookEvent, folderIDs []string) ([]*domain.Webhook, error) {
+	events, err := json.Marshal([]domain.WebhookEvent{event})
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []*domain.Webhook
+	err = conn(ctx, w.db).
+		Where("enabled = ? AND events @> ?::jsonb", true, string(events)).
+		Where("folder_id IS NULL OR folder_id IN ?", folderIDs).
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (w *webhookStoreImpl) DeleteByID(ctx context.Context, webhookID string) error {
+	return conn(ctx, w.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhookID).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", webhookID).Delete(&domain.Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return store.ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+func NewPsqlWebhookStore(db *gorm.DB) store.WebhookStore {
+	return &webhookStoreImpl{db: db}
+}
+
+type webhookDeliveryStoreImpl struct {
+	db *gorm.DB
+}
+
+func (w *webhookDeliveryStoreImpl) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return conn(ctx, w.db).Save(delivery).Error
+}
+
+func (w *webhookDeliveryStoreImpl) SaveAll(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return conn(ctx, w.db).Create(&deliveries).Error
+}
+
+func (w *webhookDeliveryStoreImpl) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+
+	err := conn(ctx, w.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(deliveries))
+		for i, delivery := range deliveries {
+			delivery.Attempts++
+			delivery.NextAttemptAt = now.Add(lease)
+			ids[i] = delivery.ID
+		}
+
+		return tx.Model(&domain.WebhookDelivery{}).
+			Where("id IN (?)", ids).
+			Updates(map[string]interface{}{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookDeliveryStoreImpl) FindByWebhook(ctx context.Context, webhookID string, page, pageSize int) ([]*domain.WebhookDelivery, int64, error) {
+	var deliveries []*domain.WebhookDelivery
+	var count int64
+
+	query := conn(ctx, w.db).Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Scopes(store.PaginationWithParams(page, pageSize)).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, count, nil
+}
+
+func NewPsqlWebhookDeliveryStore(db *gorm.DB) store.WebhookDeliveryStore {
+	return &webhookDeliveryStoreImpl{db: db}
+}
@@ -0,0 +1,142 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/store"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisEventStream struct {
+	client        *redis.Client
+	prefixStream  string
+	prefixChannel string
+	historyLimit  int64
+	historyTTL    time.Duration
+}
+
+type streamMessage struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Publish appends to the topic history before fanning out, so clients resume from the same ID.
+func (r *redisEventStream) Publish(ctx context.Context, topic, eventType string, data []byte) (*store.StreamEvent, error) {
+	streamKey := r.buildStreamKey(topic)
+	id, err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		MaxLen: r.historyLimit,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": string(data)},
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to append event to redis: %w", err)
+	}
+
+	encoded, err := json.Marshal(streamMessage{ID: id, Type: eventType, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, streamKey, r.historyTTL)
+		pipe.Publish(ctx, r.buildChannel(topic), encoded)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish event in redis: %w", err)
+	}
+
+	return &store.StreamEvent{ID: id, Topic: topic, Type: eventType, Data: data}, nil
+}
+
+func (r *redisEventStream) Replay(ctx context.Context, topic, afterID string) ([]*store.StreamEvent, error) {
+	messages, err := r.client.XRangeN(ctx, r.buildStreamKey(topic), "("+afterID, "+", r.historyLimit).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event history from redis: %w", err)
+	}
+
+	events := make([]*store.StreamEvent, 0, len(messages))
+	for _, message := range messages {
+		eventType, _ := message.Values["type"].(string)
+		data, _ := message.Values["data"].(string)
+		events = append(events, &store.StreamEvent{
+			ID:    message.ID,
+			Topic: topic,
+			Type:  eventType,
+			Data:  []byte(data),
+		})
+	}
+	return events, nil
+}
+
+// Subscribe listens to all topics at once; the returned channel is closed when ctx is done.
+func (r *redisEventStream) Subscribe(ctx context.Context) (<-chan *store.StreamEvent, error) {
+	pubsub := r.client.PSubscribe(ctx, r.buildChannel("*"))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to events in redis: %w", err)
+	}
+
+	events := make(chan *store.StreamEvent)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var decoded streamMessage
+				if err := json.Unmarshal([]byte(message.Payload), &decoded); err != nil {
+					log.Printf("REALTIME_ERROR: malformed event on %s: %v", message.Channel, err)
+					continue
+				}
+
+				event := &store.StreamEvent{
+					ID:    decoded.ID,
+					Topic: strings.TrimPrefix(message.Channel, r.prefixChannel+":"),
+					Type:  decoded.Type,
+					Data:  decoded.Data,
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (r *redisEventStream) buildStreamKey(topic string) string {
+	return fmt.Sprintf("%s:%s", r.prefixStream, topic)
+}
+
+func (r *redisEventStream) buildChannel(topic string) string {
+	return fmt.Sprintf("%s:%s", r.prefixChannel, topic)
+}
+
+func NewRedisEventStream(client *redis.Client, historyLimit int) store.EventStream {
+	return &redisEventStream{
+		client:        client,
+		prefixStream:  "event_stream",
+		prefixChannel: "event_channel",
+		historyLimit:  int64(historyLimit),
+		historyTTL:    24 * time.Hour,
+	}
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/pesos228/bug-tracker/internal/domain"
@@ -14,6 +15,14 @@ type SessionData struct {
 	AbsoluteExpiry int64  `json:"absolute_expiry"`
 }
 
+// StreamEvent is a published event; ID is assigned by the stream and is monotonic per topic.
+type StreamEvent struct {
+	ID    string
+	Topic string
+	Type  string
+	Data  []byte
+}
+
 type SearchTaskQueryByFolderID struct {
 	FolderID    string
 	RequestID   string
@@ -30,6 +39,41 @@ type SearchTaskQueryByUserID struct {
 	RequestID   string
 }
 
+type SearchFoldersQuery struct {
+	Page     int
+	PageSize int
+	Query    string
+	MemberID string
+}
+
+type FolderFilterQuery struct {
+	IDs         []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MemberID    string
+	MemberRoles []domain.FolderRole
+}
+
+type TaskFilterQuery struct {
+	FolderID        string
+	CheckStatuses   []string
+	CheckResults    []string
+	AssigneeIDs     []string
+	TestEnvDateFrom *time.Time
+	TestEnvDateTo   *time.Time
+	CheckDateFrom   *time.Time
+	CheckDateTo     *time.Time
+	Sort            string
+	SortDesc        bool
+}
+
+type InboxQuery struct {
+	UserID     string
+	UnreadOnly bool
+	Page       int
+	PageSize   int
+}
+
 type SearchUsersQuery struct {
 	Page     int
 	PageSize int
@@ -42,6 +86,58 @@ type TaskCountResult struct {
 	CompletedTasksCount  int
 }
 
+type TaskCountsQuery struct {
+	UserIDs            []string
+	FolderID           string
+	InProgressStatuses []domain.CheckStatus
+	CompletedStatuses  []domain.CheckStatus
+}
+
+type TeamWorkloadQuery struct {
+	FolderID string
+	From     time.Time
+	To       time.Time
+}
+
+type TeamWorkloadResult struct {
+	UserID             string
+	FirstName          string
+	LastName           string
+	AssignedCount      int64
+	CheckedCount       int64
+	FailedCount        int64
+	WarningCount       int64
+	MedianCheckSeconds *float64
+}
+
+type GroupCountResult struct {
+	Value string
+	Count int64
+}
+
+type AssigneeStatsResult struct {
+	UserID          string
+	FirstName       string
+	LastName        string
+	TotalCount      int64
+	NotCheckedCount int64
+	FailedCount     int64
+}
+
+type FolderStatsResult struct {
+	StatusCounts    []*GroupCountResult
+	ResultCounts    []*GroupCountResult
+	Assignees       []*AssigneeStatsResult
+	OldestUnchecked *domain.Task
+	AvgCheckSeconds *float64
+}
+
+type FolderStatusCountResult struct {
+	FolderID    string
+	CheckStatus domain.CheckStatus
+	Count       int64
+}
+
 type FolderSearchResult struct {
 	domain.Folder
 	TaskCount int64
@@ -53,13 +149,24 @@ type TasksWithUserInfo struct {
 	LastName  string
 }
 
+type FolderMemberWithUserInfo struct {
+	domain.FolderMember
+	FirstName string
+	LastName  string
+}
+
 type PreloadOption string
 
 const (
 	WithTasks   PreloadOption = "Tasks"
 	WithCreator PreloadOption = "Creator"
+	WithOwner   PreloadOption = "Owner"
 )
 
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type StateStore interface {
 	SetState(ctx context.Context, state string) error
 	GetState(ctx context.Context, state string) (string, error)
@@ -73,28 +180,145 @@ type SessionStore interface {
 	CheckSession(ctx context.Context, sessionId string) (bool, error)
 }
 
+type EventStream interface {
+	Publish(ctx context.Context, topic, eventType string, data []byte) (*StreamEvent, error)
+	Replay(ctx context.Context, topic, afterID string) ([]*StreamEvent, error)
+	Subscribe(ctx context.Context) (<-chan *StreamEvent, error)
+}
+
 type UserStore interface {
 	Save(ctx context.Context, user *domain.User) error
+	SyncIdentity(ctx context.Context, user *domain.User) error
+	SaveProfile(ctx context.Context, user *domain.User) error
 	FindById(ctx context.Context, userId string, preloads ...PreloadOption) (*domain.User, error)
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	IsExists(ctx context.Context, userId string) (bool, error)
 	FindAll(ctx context.Context, page, pageSize int, preloads ...PreloadOption) ([]*domain.User, int64, error)
 	Search(ctx context.Context, params *SearchUsersQuery) ([]*domain.User, int64, error)
+	FindByFullName(ctx context.Context, fullName string) ([]*domain.User, error)
 }
 
 type TaskStore interface {
 	Save(ctx context.Context, task *domain.Task) error
+	SaveAll(ctx context.Context, tasks []*domain.Task) error
 	FindById(ctx context.Context, taskId string) (*domain.Task, error)
+	FindByFilter(ctx context.Context, params *TaskFilterQuery) ([]*domain.Task, error)
+	FindByIDs(ctx context.Context, taskIDs []string) ([]*domain.Task, error)
 	FindByUserId(ctx context.Context, page, pageSize int, userId string) ([]*domain.Task, int64, error)
-	FindByFolderIdWithUserInfo(ctx context.Context, folderID string) ([]*TasksWithUserInfo, error)
+	IterateByFilterWithUserInfo(ctx context.Context, params *TaskFilterQuery, fn func(task *TasksWithUserInfo) error) error
 	SearchByFolderID(ctx context.Context, params *SearchTaskQueryByFolderID) ([]*domain.Task, int64, error)
 	SearchByUserID(ctx context.Context, params *SearchTaskQueryByUserID) ([]*domain.Task, int64, error)
 	DeleteByID(ctx context.Context, taskID string) error
-	GetTaskCountsForUsers(ctx context.Context, userIDs []string, inProgressStatuses, completedStatuses []domain.CheckStatus) ([]*TaskCountResult, error)
+	GetTaskCountsForUsers(ctx context.Context, query *TaskCountsQuery) ([]*TaskCountResult, error)
+	GetTeamWorkload(ctx context.Context, params *TeamWorkloadQuery) ([]*TeamWorkloadResult, error)
+	GetFolderStats(ctx context.Context, folderID string) (*FolderStatsResult, error)
+	GetStatusCountsByFolder(ctx context.Context) ([]*FolderStatusCountResult, error)
 }
 
 type FolderStore interface {
 	Save(ctx context.Context, folder *domain.Folder) error
-	Search(ctx context.Context, page, pageSize int, query string) ([]*FolderSearchResult, int64, error)
+	Search(ctx context.Context, params *SearchFoldersQuery) ([]*FolderSearchResult, int64, error)
 	IsExists(ctx context.Context, folderId string) (bool, error)
 	FindByID(ctx context.Context, folderID string, preloads ...PreloadOption) (*domain.Folder, error)
+	FindByFilter(ctx context.Context, filter *FolderFilterQuery, preloads ...PreloadOption) ([]*domain.Folder, error)
+}
+
+type FolderMemberStore interface {
+	Save(ctx context.Context, member *domain.FolderMember) error
+	Find(ctx context.Context, folderID, userID string) (*domain.FolderMember, error)
+	FindByFolderIDWithUserInfo(ctx context.Context, folderID string) ([]*FolderMemberWithUserInfo, error)
+	CountByRole(ctx context.Context, folderID string, role domain.FolderRole) (int64, error)
+	HasAnyRole(ctx context.Context, userID string, roles []domain.FolderRole) (bool, error)
+	FindByUserID(ctx context.Context, userID string) ([]*domain.FolderMember, error)
+	Delete(ctx context.Context, folderID, userID string) error
+}
+
+type SnapshotStore interface {
+	Upsert(ctx context.Context, snapshots []*domain.FolderSnapshot) error
+	FindByFolderID(ctx context.Context, folderID string, from, to time.Time) ([]*domain.FolderSnapshot, error)
+	FindLatest(ctx context.Context) ([]*domain.FolderSnapshot, error)
+}
+
+type ReportJobStore interface {
+	Save(ctx context.Context, job *domain.ReportJob) error
+	FindByID(ctx context.Context, jobID string) (*domain.ReportJob, error)
+	ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*domain.ReportJob, error)
+	ExtendLease(ctx context.Context, jobID string, until time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) ([]*domain.ReportJob, error)
+}
+
+type ReportFileStore interface {
+	Save(ctx context.Context, key string, write func(w io.Writer) error) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type NotificationStore interface {
+	Save(ctx context.Context, notification *domain.Notification) error
+	SaveAll(ctx context.Context, notifications []*domain.Notification) error
+	FindByID(ctx context.Context, notificationID string) (*domain.Notification, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Notification, error)
+	FindDead(ctx context.Context, page, pageSize int) ([]*domain.Notification, int64, error)
+	FindDigestRecipients(ctx context.Context) ([]string, error)
+	ClaimDigest(ctx context.Context, recipientID string, now time.Time, lease time.Duration) ([]*domain.Notification, error)
+}
+
+type WebhookStore interface {
+	Save(ctx context.Context, webhook *domain.Webhook) error
+	FindByID(ctx context.Context, webhookID string) (*domain.Webhook, error)
+	FindAll(ctx context.Context, page, pageSize int) ([]*domain.Webhook, int64, error)
+	FindSubscribed(ctx context.Context, event domain.WebhookEvent, folderIDs []string) ([]*domain.Webhook, error)
+	DeleteByID(ctx context.Context, webhookID string) error
+}
+
+type WebhookDeliveryStore interface {
+	Save(ctx context.Context, delivery *domain.WebhookDelivery) error
+	SaveAll(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error)
+	FindByWebhook(ctx context.Context, webhookID string, page, pageSize int) ([]*domain.WebhookDelivery, int64, error)
+}
+
+type NotificationSettingsStore interface {
+	Save(ctx context.Context, settings *domain.NotificationSettings) error
+	FindByUser(ctx context.Context, userID string) (*domain.NotificationSettings, error)
+}
+
+type InboxStore interface {
+	SaveAll(ctx context.Context, items []*domain.InboxItem) error
+	FindByUser(ctx context.Context, params *InboxQuery) ([]*domain.InboxItem, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID, itemID string, now time.Time) error
+	MarkAllRead(ctx context.Context, userID string, now time.Time) (int64, error)
+}
+
+type ReportTemplateStore interface {
+	Save(ctx context.Context, template *domain.ReportTemplate) error
+	FindByID(ctx context.Context, templateID string) (*domain.ReportTemplate, error)
+	FindAll(ctx context.Context) ([]*domain.ReportTemplate, error)
+	FindDefault(ctx context.Context) (*domain.ReportTemplate, error)
+	Delete(ctx context.Context, templateID string) error
+}
+
+type MentionStore interface {
+	SaveAll(ctx context.Context, mentions []*domain.TaskMention) error
+	IsMentioned(ctx context.Context, taskID, userID string) (bool, error)
+	DeleteExcept(ctx context.Context, taskID string, userIDs []string) error
+	CountUsers(ctx context.Context, taskID string) (int64, error)
+}
+
+type EmailTemplateStore interface {
+	Save(ctx context.Context, template *domain.EmailTemplate) error
+	Find(ctx context.Context, name, locale string) (*domain.EmailTemplate, error)
+	FindAll(ctx context.Context) ([]*domain.EmailTemplate, error)
+	Delete(ctx context.Context, name, locale string) error
+}
+
+type ReportScheduleStore interface {
+	Save(ctx context.Context, schedule *domain.ReportSchedule) error
+	FindByID(ctx context.Context, scheduleID string) (*domain.ReportSchedule, error)
+	FindByCreator(ctx context.Context, userID string) ([]*domain.ReportSchedule, error)
+	FindDueForUpdate(ctx context.Context, now time.Time) (*domain.ReportSchedule, error)
+	Delete(ctx context.Context, scheduleID string) error
+	RecordRun(ctx context.Context, schedule *domain.ReportSchedule, run *domain.ReportScheduleRun) error
+	FindRuns(ctx context.Context, scheduleID string, page, pageSize int) ([]*domain.ReportScheduleRun, int64, error)
 }
@@ -0,0 +1,23 @@
+package textreport
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+func headers(layout *service.ReportLayout) []string {
+	headers := layout.Headers()
+	for i, header := range headers {
+		headers[i] = strings.Join(strings.Fields(header), " ")
+	}
+	return headers
+}
+
+func formatOptionalDate(layout *service.ReportLayout, t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return layout.FormatDate(*t)
+}
@@ -0,0 +1,66 @@
+package textreport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type csvGenerator struct {
+}
+
+func (c *csvGenerator) Format() service.ReportFormat {
+	return service.FormatCSV
+}
+
+func (c *csvGenerator) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (c *csvGenerator) Generate(layout *service.ReportLayout, meta *service.ReportMeta, tasks []*service.TaskReportRow) (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+	if err := c.Stream(buffer, layout, meta, service.SliceTaskRows(tasks)); err != nil {
+		return nil, err
+	}
+
+	return buffer, nil
+}
+
+func (c *csvGenerator) Stream(w io.Writer, layout *service.ReportLayout, meta *service.ReportMeta, tasks service.TaskRowSource) error {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+
+	if err := writer.Write(headers(layout)); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	err := tasks(func(task *service.TaskReportRow) error {
+		if err := writer.Write(layout.Texts(task)); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return nil
+}
+
+func NewCSVGenerator() service.ReportGenerator {
+	return &csvGenerator{}
+}
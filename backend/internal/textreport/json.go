@@ -0,0 +1,89 @@
+package textreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pesos228/bug-tracker/internal/domain"
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+type jsonReport struct {
+	FolderName       string                               `json:"folderName"`
+	ReleaseVersion   string                               `json:"releaseVersion,omitempty"`
+	CreatorPerson    string                               `json:"creatorPerson,omitempty"`
+	CreatedAt        time.Time                            `json:"createdAt"`
+	PlannedStartDate *time.Time                           `json:"plannedStartDate,omitempty"`
+	PlannedEndDate   *time.Time                           `json:"plannedEndDate,omitempty"`
+	GeneratedAt      time.Time                            `json:"generatedAt"`
+	Tasks            []map[domain.ReportField]interface{} `json:"tasks"`
+}
+
+type jsonGenerator struct {
+}
+
+func (j *jsonGenerator) Format() service.ReportFormat {
+	return service.FormatJSON
+}
+
+func (j *jsonGenerator) ContentType() string {
+	return "application/json"
+}
+
+func (j *jsonGenerator) Generate(layout *service.ReportLayout, meta *service.ReportMeta, tasks []*service.TaskReportRow) (*bytes.Buffer, error) {
+	report := jsonReport{
+		FolderName:       meta.FolderName,
+		ReleaseVersion:   meta.ReleaseVersion,
+		CreatorPerson:    meta.CreatorPerson,
+		CreatedAt:        meta.CreatedAt,
+		PlannedStartDate: meta.PlannedStartDate,
+		PlannedEndDate:   meta.PlannedEndDate,
+		GeneratedAt:      meta.GeneratedAt,
+		Tasks:            make([]map[domain.ReportField]interface{}, len(tasks)),
+	}
+
+	for i, task := range tasks {
+		report.Tasks[i] = j.taskValues(layout, task)
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return nil, fmt.Errorf("failed to write in buffer: %w", err)
+	}
+
+	return buffer, nil
+}
+
+func (j *jsonGenerator) taskValues(layout *service.ReportLayout, task *service.TaskReportRow) map[domain.ReportField]interface{} {
+	values := make(map[domain.ReportField]interface{}, len(layout.Columns))
+	for _, column := range layout.Columns {
+		switch column.Field {
+		case domain.FieldTestEnvDateUpdate:
+			values[column.Field] = optionalTime(task.TestEnvDateUpdate)
+		case domain.FieldCheckDate:
+			values[column.Field] = optionalTime(task.CheckDate)
+		case domain.FieldCheckStatus:
+			values[column.Field] = task.CheckStatus
+		case domain.FieldCheckResult:
+			values[column.Field] = task.CheckResult
+		default:
+			values[column.Field] = layout.Value(column.Field, task)
+		}
+	}
+	return values
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func NewJSONGenerator() service.ReportGenerator {
+	return &jsonGenerator{}
+}
@@ -0,0 +1,70 @@
+package textreport
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pesos228/bug-tracker/internal/service"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"|", "\\|",
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+type markdownGenerator struct {
+}
+
+func (m *markdownGenerator) Format() service.ReportFormat {
+	return service.FormatMarkdown
+}
+
+func (m *markdownGenerator) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+func (m *markdownGenerator) Generate(layout *service.ReportLayout, meta *service.ReportMeta, tasks []*service.TaskReportRow) (*bytes.Buffer, error) {
+	buffer := &bytes.Buffer{}
+
+	fmt.Fprintf(buffer, "# %s\n\n", layout.T("report.title_with_folder", markdownEscaper.Replace(meta.FolderName)))
+
+	if meta.ReleaseVersion != "" {
+		fmt.Fprintf(buffer, "- **%s:** %s\n", layout.T("report.release_version"), markdownEscaper.Replace(meta.ReleaseVersion))
+	}
+	if meta.CreatorPerson != "" {
+		fmt.Fprintf(buffer, "- **%s:** %s\n", layout.T("report.creator"), markdownEscaper.Replace(meta.CreatorPerson))
+	}
+	fmt.Fprintf(buffer, "- **%s:** %s\n", layout.T("report.created_at"), layout.FormatDate(meta.CreatedAt))
+	fmt.Fprintf(buffer, "- **%s:** %s — %s\n", layout.T("report.planned_dates"), formatOptionalDate(layout, meta.PlannedStartDate), formatOptionalDate(layout, meta.PlannedEndDate))
+	fmt.Fprintf(buffer, "- **%s:** %s\n", layout.T("report.generated_at"), meta.GeneratedAt.Format("02.01.2006 15:04"))
+	fmt.Fprintf(buffer, "- **%s:** %d\n\n", layout.T("report.total_tasks"), len(tasks))
+
+	m.writeRow(buffer, headers(layout))
+	separators := make([]string, len(layout.Columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	buffer.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+
+	for _, task := range tasks {
+		m.writeRow(buffer, layout.Texts(task))
+	}
+
+	return buffer, nil
+}
+
+func (m *markdownGenerator) writeRow(buffer *bytes.Buffer, values []string) {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = markdownEscaper.Replace(strings.TrimSpace(value))
+	}
+	buffer.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+func NewMarkdownGenerator() service.ReportGenerator {
+	return &markdownGenerator{}
+}
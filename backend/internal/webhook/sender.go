@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const maxResponseRead = 4096
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+type Sender interface {
+	Send(ctx context.Context, request *Request) (*Response, error)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+func (h *httpSender) Send(ctx context.Context, request *Request) (*Response, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build the request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("User-Agent", "bug-tracker-webhooks")
+	httpRequest.Header.Set(HeaderEvent, request.Event)
+	httpRequest.Header.Set(HeaderDelivery, request.DeliveryID)
+	httpRequest.Header.Set(HeaderTimestamp, timestamp)
+	httpRequest.Header.Set(HeaderSignature, "sha256="+Sign(request.Secret, timestamp, request.Body))
+
+	httpResponse, err := h.client.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't send the request: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseRead))
+	response := &Response{StatusCode: httpResponse.StatusCode, Body: string(body)}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		return response, fmt.Errorf("unexpected response status %d", httpResponse.StatusCode)
+	}
+
+	return response, nil
+}
+
+// Sign computes HMAC-SHA256 over "<timestamp>.<body>", so receivers can reject replayed requests.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewHTTPSender doesn't follow redirects, so a receiver can't bounce a signed payload to another host.
+func NewHTTPSender(timeout time.Duration) Sender {
+	return &httpSender{client: &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
@@ -28,6 +28,7 @@ export const deleteFolder = async (folderId) => {
 
 export const exportFolder = async (folderId) => {
   const response = await apiClient.get(`/folders/${folderId}/reports`, {
+    params: { format: 'xlsx' },
     responseType: 'blob',
   });
   return response;